@@ -21,10 +21,11 @@ resource "thetalake_case" "test" {
 }
 
 resource "thetalake_user" "test" {
-  name     = "Test User"
-  email    = "test_user@example.com"
-  password = "SecurePassword123!"
-  role_id  = 1
+  name                  = "Test User"
+  email                 = "test_user@example.com"
+  password              = "SecurePassword123!"
+  password_confirmation = "SecurePassword123!"
+  role_id               = 1
 }
 
 resource "thetalake_directory_group" "test" {
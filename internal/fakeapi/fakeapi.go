@@ -0,0 +1,757 @@
+// Package fakeapi implements an in-memory Theta Lake API on top of
+// httptest.Server. It keeps state between requests, so a provider test can
+// create, read, update, import and destroy resources without a live tenant.
+package fakeapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// Token is the bearer token the server accepts unless Server.Token is changed.
+const Token = "fakeapi-token"
+
+// Server is a stateful fake of the Theta Lake API. The zero value is not
+// usable; call New or NewServer.
+type Server struct {
+	*httptest.Server
+
+	// Token is the bearer token requests must carry. An empty Token accepts
+	// every request.
+	Token string
+
+	mu     sync.Mutex
+	nextID int
+
+	cases             map[int]*thetalake.Case
+	caseRecords       map[int]map[string]bool
+	caseMembers       map[int]*thetalake.CaseMember
+	users             map[int]*thetalake.User
+	passwordChanges   map[int]int
+	roles             map[int]*thetalake.Role
+	directoryGroups   map[int]*thetalake.DirectoryGroup
+	retentionPolicies map[int]*thetalake.RetentionPolicy
+	legalHolds        map[int]*thetalake.LegalHold
+	tags              map[int]*thetalake.Tag
+	exports           map[int]*thetalake.Export
+	records           map[string]*thetalake.Record
+	integrations      map[string]*thetalake.IntegrationState
+	analyses          map[string]*thetalake.Analysis
+	analysisPolicies  []thetalake.AnalysisPolicy
+	policyHits        []thetalake.PolicyHit
+	auditLogs         []thetalake.AuditLog
+	events            []thetalake.Event
+	systemStatus      thetalake.SystemStatus
+}
+
+// New starts a server that is closed when the test finishes.
+func New(t testing.TB) *Server {
+	t.Helper()
+
+	s := NewServer()
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// NewServer starts a server. The caller must Close it.
+func NewServer() *Server {
+	s := &Server{
+		Token:             Token,
+		nextID:            1000,
+		cases:             map[int]*thetalake.Case{},
+		caseRecords:       map[int]map[string]bool{},
+		caseMembers:       map[int]*thetalake.CaseMember{},
+		users:             map[int]*thetalake.User{},
+		passwordChanges:   map[int]int{},
+		roles:             map[int]*thetalake.Role{},
+		directoryGroups:   map[int]*thetalake.DirectoryGroup{},
+		retentionPolicies: map[int]*thetalake.RetentionPolicy{},
+		legalHolds:        map[int]*thetalake.LegalHold{},
+		tags:              map[int]*thetalake.Tag{},
+		exports:           map[int]*thetalake.Export{},
+		records:           map[string]*thetalake.Record{},
+		integrations:      map[string]*thetalake.IntegrationState{},
+		analyses:          map[string]*thetalake.Analysis{},
+		systemStatus:      thetalake.SystemStatus{Status: "ok", Version: "fake"},
+	}
+
+	mux := http.NewServeMux()
+	s.routes(mux)
+	s.Server = httptest.NewServer(s.authenticate(mux))
+
+	return s
+}
+
+func (s *Server) routes(mux *http.ServeMux) {
+	handleCRUD(s, mux, "/cases", s.cases, func(c *thetalake.Case) *int { return &c.ID }, prepareCase)
+	mux.HandleFunc("GET /cases", s.searchCases)
+	mux.HandleFunc("PUT /cases/{id}/open", s.setCaseStatus(thetalake.CaseStatusOpen))
+	mux.HandleFunc("PUT /cases/{id}/close", s.setCaseStatus(thetalake.CaseStatusClosed))
+	mux.HandleFunc("GET /cases/{id}/records", s.listCaseRecords)
+	mux.HandleFunc("POST /cases/{id}/records", s.addCaseRecord)
+	mux.HandleFunc("DELETE /cases/{id}/records", s.removeCaseRecord)
+	mux.HandleFunc("DELETE /cases/{id}/records/{record_id}", s.removeCaseRecord)
+	mux.HandleFunc("POST /cases/{id}/members", s.createCaseMember)
+	mux.HandleFunc("GET /cases/{id}/members/{member_id}", s.getCaseMember)
+	mux.HandleFunc("PUT /cases/{id}/members/{member_id}", s.updateCaseMember)
+	mux.HandleFunc("DELETE /cases/{id}/members/{member_id}", s.deleteCaseMember)
+
+	handleCRUD(s, mux, "/users", s.users, func(u *thetalake.User) *int { return &u.ID }, prepareUser)
+	mux.HandleFunc("GET /users", s.searchUsers)
+	mux.HandleFunc("PUT /users/{id}/password", s.setUserPassword)
+	mux.HandleFunc("PUT /users/{id}/enable", s.setUserActive(true))
+	mux.HandleFunc("PUT /users/{id}/disable", s.setUserActive(false))
+
+	handleCRUD(s, mux, "/roles", s.roles, func(r *thetalake.Role) *int { return &r.ID }, prepareRole)
+	mux.HandleFunc("GET /roles", s.listRoles)
+	handleCRUD(s, mux, "/directory_groups", s.directoryGroups, func(g *thetalake.DirectoryGroup) *int { return &g.ID }, nil)
+	handleCRUD(s, mux, "/retention_policies", s.retentionPolicies, func(p *thetalake.RetentionPolicy) *int { return &p.ID }, nil)
+	handleCRUD(s, mux, "/legal_holds", s.legalHolds, func(h *thetalake.LegalHold) *int { return &h.ID }, nil)
+	handleCRUD(s, mux, "/tags", s.tags, func(t *thetalake.Tag) *int { return &t.ID }, nil)
+	handleCRUD(s, mux, "/exports", s.exports, func(e *thetalake.Export) *int { return &e.ID }, prepareExport)
+
+	mux.HandleFunc("GET /records/{id}", s.getRecord)
+	mux.HandleFunc("PUT /records/{id}/review_state", s.updateRecordReviewState)
+
+	mux.HandleFunc("GET /ingestion/integration/{id}/state", s.getIntegrationState)
+	mux.HandleFunc("PUT /ingestion/integration/{id}/state", s.updateIntegrationState)
+
+	mux.HandleFunc("GET /analysis/policies", s.listAnalysisPolicies)
+	mux.HandleFunc("GET /analysis/policy_hits", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writePage(w, r, "hits", s.policyHits)
+	})
+	mux.HandleFunc("GET /analysis/{id}", s.getAnalysis)
+
+	mux.HandleFunc("GET /audit_logs", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writePage(w, r, "audit_logs", s.auditLogs)
+	})
+	mux.HandleFunc("GET /events", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writePage(w, r, "events", s.events)
+	})
+
+	mux.HandleFunc("GET /system/status", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		writeJSON(w, http.StatusOK, s.systemStatus)
+	})
+}
+
+// authenticate rejects requests without the expected bearer token.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.Token != "" && r.Header.Get("Authorization") != "Bearer "+s.Token {
+			writeError(w, http.StatusUnauthorized, "invalid or missing bearer token")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleCRUD registers create, read, update and delete handlers for a
+// collection of objects identified by an integer ID. prepare, when set,
+// validates an incoming object and fills in the fields the API computes. On
+// update it also receives the stored object, nil on create.
+func handleCRUD[T any](s *Server, mux *http.ServeMux, path string, items map[int]*T, id func(*T) *int, prepare func(item, prior *T) error) {
+	mux.HandleFunc("POST "+path, func(w http.ResponseWriter, r *http.Request) {
+		item := new(T)
+		if !decode(w, r, item) {
+			return
+		}
+		if prepare != nil {
+			if err := prepare(item, nil); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err.Error())
+				return
+			}
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.nextID++
+		*id(item) = s.nextID
+		items[s.nextID] = item
+		writeJSON(w, http.StatusCreated, item)
+	})
+
+	mux.HandleFunc("GET "+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		item, ok := lookup(w, r, items)
+		if !ok {
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	})
+
+	mux.HandleFunc("PUT "+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		update := new(T)
+		if !decode(w, r, update) {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		item, ok := lookup(w, r, items)
+		if !ok {
+			return
+		}
+		if prepare != nil {
+			if err := prepare(update, item); err != nil {
+				writeError(w, http.StatusUnprocessableEntity, err.Error())
+				return
+			}
+		}
+		*id(update) = *id(item)
+		*item = *update
+		writeJSON(w, http.StatusOK, item)
+	})
+
+	mux.HandleFunc("DELETE "+path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := lookup(w, r, items); !ok {
+			return
+		}
+		delete(items, pathID(r))
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// prepareCase defaults the open date to today, as the API does. The status and
+// close date only change through the open and close endpoints.
+func prepareCase(c, prior *thetalake.Case) error {
+	if c.Name == "" || c.Number == "" {
+		return fmt.Errorf("name and number are required")
+	}
+	if c.Visibility != "" && c.Visibility != "PUBLIC" && c.Visibility != "PRIVATE" {
+		return fmt.Errorf("visibility must be PUBLIC or PRIVATE")
+	}
+	if c.OpenDate == "" {
+		c.OpenDate = time.Now().UTC().Format(time.DateOnly)
+	}
+	if prior != nil {
+		c.Status, c.CloseDate = prior.Status, prior.CloseDate
+	} else {
+		c.Status, c.CloseDate = thetalake.CaseStatusOpen, ""
+	}
+
+	return nil
+}
+
+// prepareUser mimics the API: passwords are write-only and must match. New
+// users are active, and only the enable and disable endpoints change that.
+// Group membership comes from the directory sync and cannot be set.
+func prepareUser(u, prior *thetalake.User) error {
+	if u.Name == "" || u.Email == "" {
+		return fmt.Errorf("name and email are required")
+	}
+	if u.Password != u.PasswordConfirmation {
+		return fmt.Errorf("password confirmation does not match")
+	}
+	u.Password = ""
+	u.PasswordConfirmation = ""
+	if prior != nil {
+		u.Active, u.DirectoryGroupIDs = prior.Active, prior.DirectoryGroupIDs
+	} else {
+		u.Active = new(bool)
+		*u.Active = true
+		u.DirectoryGroupIDs = nil
+	}
+
+	return nil
+}
+
+// searchUsers lists the users matching the email, email_domain, role_id,
+// directory_group_id and active query parameters, in creation order.
+func (s *Server) searchUsers(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := thetalake.UserFilter{Email: q.Get("email"), EmailDomain: q.Get("email_domain")}
+	filter.RoleID, _ = strconv.Atoi(q.Get("role_id"))
+	filter.DirectoryGroupID, _ = strconv.Atoi(q.Get("directory_group_id"))
+	if active, err := strconv.ParseBool(q.Get("active")); err == nil {
+		filter.Active = &active
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	users := []thetalake.User{}
+	for _, id := range slices.Sorted(maps.Keys(s.users)) {
+		if filter.Match(*s.users[id]) {
+			users = append(users, *s.users[id])
+		}
+	}
+	writePage(w, r, "users", users)
+}
+
+func (s *Server) setUserPassword(w http.ResponseWriter, r *http.Request) {
+	var body thetalake.User
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Password == "" || body.Password != body.PasswordConfirmation {
+		writeError(w, http.StatusUnprocessableEntity, "password confirmation does not match")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := lookup(w, r, s.users); !ok {
+		return
+	}
+	s.passwordChanges[pathID(r)]++
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) setUserActive(active bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		u, ok := lookup(w, r, s.users)
+		if !ok {
+			return
+		}
+		u.Active = new(bool)
+		*u.Active = active
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// prepareRole mimics the API: only custom roles can be created or changed.
+func prepareRole(role, prior *thetalake.Role) error {
+	if role.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if prior != nil && prior.BuiltIn {
+		return fmt.Errorf("built-in role %q cannot be changed", prior.Name)
+	}
+	role.BuiltIn = false
+	if role.Permissions == nil {
+		role.Permissions = []string{}
+	}
+
+	return nil
+}
+
+// listRoles lists the roles matching the name query parameter, in ID order.
+func (s *Server) listRoles(w http.ResponseWriter, r *http.Request) {
+	filter := thetalake.RoleFilter{Name: r.URL.Query().Get("name")}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	roles := []thetalake.Role{}
+	for _, id := range slices.Sorted(maps.Keys(s.roles)) {
+		if filter.Match(*s.roles[id]) {
+			roles = append(roles, *s.roles[id])
+		}
+	}
+	writePage(w, r, "roles", roles)
+}
+
+// prepareExport fills in the fields the API computes for a new export.
+func prepareExport(e, _ *thetalake.Export) error {
+	if e.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if e.Status == "" {
+		e.Status = "PENDING"
+	}
+
+	return nil
+}
+
+func (s *Server) setCaseStatus(status string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		c, ok := lookup(w, r, s.cases)
+		if !ok {
+			return
+		}
+		setCaseStatus(c, status)
+		writeJSON(w, http.StatusOK, c)
+	}
+}
+
+// searchCases lists the cases matching the number, name and status query
+// parameters, in creation order.
+func (s *Server) searchCases(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	filter := thetalake.CaseFilter{Number: q.Get("number"), Name: q.Get("name"), Status: q.Get("status")}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cases := []thetalake.Case{}
+	for _, id := range slices.Sorted(maps.Keys(s.cases)) {
+		if filter.Match(*s.cases[id]) {
+			cases = append(cases, *s.cases[id])
+		}
+	}
+	writePage(w, r, "cases", cases)
+}
+
+// setCaseStatus opens or closes c, recording today as the close date.
+func setCaseStatus(c *thetalake.Case, status string) {
+	c.Status = status
+	c.CloseDate = ""
+	if status == thetalake.CaseStatusClosed {
+		c.CloseDate = time.Now().UTC().Format(time.DateOnly)
+	}
+}
+
+func (s *Server) listCaseRecords(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := lookup(w, r, s.cases); !ok {
+		return
+	}
+
+	ids := slices.Sorted(maps.Keys(s.caseRecords[pathID(r)]))
+	records := make([]thetalake.Record, 0, len(ids))
+	for _, id := range ids {
+		records = append(records, *s.records[id])
+	}
+	writePage(w, r, "records", records)
+}
+
+func (s *Server) addCaseRecord(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		RecordID string `json:"record_id"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := lookup(w, r, s.cases); !ok {
+		return
+	}
+	if _, ok := s.records[body.RecordID]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s not found", body.RecordID))
+		return
+	}
+
+	caseID := pathID(r)
+	if s.caseRecords[caseID] == nil {
+		s.caseRecords[caseID] = map[string]bool{}
+	}
+	s.caseRecords[caseID][body.RecordID] = true
+	w.WriteHeader(http.StatusCreated)
+}
+
+// removeCaseRecord accepts the record ID in the path or as a record_id query
+// parameter.
+func (s *Server) removeCaseRecord(w http.ResponseWriter, r *http.Request) {
+	recordID := r.PathValue("record_id")
+	if recordID == "" {
+		recordID = r.URL.Query().Get("record_id")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := lookup(w, r, s.cases); !ok {
+		return
+	}
+
+	caseID := pathID(r)
+	if !s.caseRecords[caseID][recordID] {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("record %s is not part of case %d", recordID, caseID))
+		return
+	}
+	delete(s.caseRecords[caseID], recordID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createCaseMember(w http.ResponseWriter, r *http.Request) {
+	member := new(thetalake.CaseMember)
+	if !decode(w, r, member) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := lookup(w, r, s.cases); !ok {
+		return
+	}
+	if err := s.validateCaseMember(member); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	caseID := pathID(r)
+	for _, m := range s.caseMembers {
+		if m.CaseID == caseID && m.UserID == member.UserID && m.DirectoryGroupID == member.DirectoryGroupID {
+			writeError(w, http.StatusConflict, "already a member of the case")
+			return
+		}
+	}
+
+	s.nextID++
+	member.ID = s.nextID
+	member.CaseID = caseID
+	s.caseMembers[member.ID] = member
+	writeJSON(w, http.StatusCreated, member)
+}
+
+func (s *Server) getCaseMember(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if member, ok := s.lookupCaseMember(w, r); ok {
+		writeJSON(w, http.StatusOK, member)
+	}
+}
+
+func (s *Server) updateCaseMember(w http.ResponseWriter, r *http.Request) {
+	var update thetalake.CaseMember
+	if !decode(w, r, &update) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	member, ok := s.lookupCaseMember(w, r)
+	if !ok {
+		return
+	}
+	if update.Role == "" {
+		writeError(w, http.StatusUnprocessableEntity, "role is required")
+		return
+	}
+
+	// Only the role can change; the user or group is fixed.
+	member.Role = update.Role
+	writeJSON(w, http.StatusOK, member)
+}
+
+func (s *Server) deleteCaseMember(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	member, ok := s.lookupCaseMember(w, r)
+	if !ok {
+		return
+	}
+	delete(s.caseMembers, member.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// lookupCaseMember finds the member named by the {member_id} path segment in
+// the case named by {id}, writing a 404 when either does not exist.
+func (s *Server) lookupCaseMember(w http.ResponseWriter, r *http.Request) (*thetalake.CaseMember, bool) {
+	if _, ok := lookup(w, r, s.cases); !ok {
+		return nil, false
+	}
+
+	memberID, _ := strconv.Atoi(r.PathValue("member_id"))
+	member, ok := s.caseMembers[memberID]
+	if !ok || member.CaseID != pathID(r) {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", strings.TrimPrefix(r.URL.Path, "/")))
+		return nil, false
+	}
+
+	return member, true
+}
+
+// validateCaseMember checks that a new member names exactly one existing user
+// or directory group and a role.
+func (s *Server) validateCaseMember(m *thetalake.CaseMember) error {
+	switch {
+	case m.Role == "":
+		return fmt.Errorf("role is required")
+	case (m.UserID == 0) == (m.DirectoryGroupID == 0):
+		return fmt.Errorf("exactly one of user_id and directory_group_id is required")
+	case m.UserID != 0 && s.users[m.UserID] == nil:
+		return fmt.Errorf("user %d not found", m.UserID)
+	case m.DirectoryGroupID != 0 && s.directoryGroups[m.DirectoryGroupID] == nil:
+		return fmt.Errorf("directory group %d not found", m.DirectoryGroupID)
+	}
+
+	return nil
+}
+
+func (s *Server) getRecord(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "record not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, record)
+}
+
+func (s *Server) updateRecordReviewState(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		ReviewState string `json:"review_state"`
+		Comment     string `json:"comment"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "record not found")
+		return
+	}
+	record.ReviewState = body.ReviewState
+	record.Comment = body.Comment
+	writeJSON(w, http.StatusOK, record)
+}
+
+// integration returns the state of an integration, creating an active one on
+// first use: every integration ID is assumed to exist.
+func (s *Server) integration(id string) *thetalake.IntegrationState {
+	state, ok := s.integrations[id]
+	if !ok {
+		state = &thetalake.IntegrationState{
+			LastRun:    "2024-01-01T00:00:00Z",
+			LastUpload: "2024-01-01T00:00:00Z",
+		}
+		s.integrations[id] = state
+	}
+
+	return state
+}
+
+func (s *Server) getIntegrationState(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]any{"state": s.integration(r.PathValue("id"))})
+}
+
+func (s *Server) updateIntegrationState(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Status string `json:"status"`
+	}
+	if !decode(w, r, &body) {
+		return
+	}
+	if body.Status != "active" && body.Status != "paused" {
+		writeError(w, http.StatusUnprocessableEntity, "status must be active or paused")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state := s.integration(r.PathValue("id"))
+	state.Paused = body.Status == "paused"
+	writeJSON(w, http.StatusOK, map[string]any{"state": state})
+}
+
+func (s *Server) listAnalysisPolicies(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	policies := s.analysisPolicies
+	if policies == nil {
+		policies = []thetalake.AnalysisPolicy{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"policies": policies})
+}
+
+func (s *Server) getAnalysis(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	analysis, ok := s.analyses[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "analysis not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, analysis)
+}
+
+// writePage serves one page of items using the cursor protocol the client
+// expects: the cursor is the offset of the first item on the page.
+func writePage[T any](w http.ResponseWriter, r *http.Request, key string, items []T) {
+	start, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if size <= 0 {
+		size = 100
+	}
+
+	start = min(max(start, 0), len(items))
+	end := min(start+size, len(items))
+
+	page := map[string]any{key: append([]T{}, items[start:end]...)}
+	if end < len(items) {
+		page["next_cursor"] = strconv.Itoa(end)
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+func pathID(r *http.Request) int {
+	id, _ := strconv.Atoi(r.PathValue("id"))
+	return id
+}
+
+// lookup finds the object named by the {id} path segment, writing a 404 when
+// it does not exist.
+func lookup[T any](w http.ResponseWriter, r *http.Request, items map[int]*T) (*T, bool) {
+	item, ok := items[pathID(r)]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s not found", strings.TrimPrefix(r.URL.Path, "/")))
+		return nil, false
+	}
+
+	return item, true
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %s", err))
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, thetalake.ErrorPayload{Message: message})
+}
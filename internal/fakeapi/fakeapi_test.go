@@ -0,0 +1,317 @@
+package fakeapi
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func newClient(t *testing.T, s *Server) *thetalake.Client {
+	t.Helper()
+
+	c, err := thetalake.NewClient(s.URL, Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.MaxRetries = 0
+
+	return c
+}
+
+func TestCaseLifecycle(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+	ctx := t.Context()
+
+	created, err := c.CreateCase(ctx, thetalake.Case{Name: "case", Number: "CASE-1", Visibility: "PRIVATE"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := created.ID
+
+	if _, err := c.UpdateCase(ctx, strconv.Itoa(id), thetalake.Case{Name: "renamed", Number: "CASE-1"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetCase(ctx, strconv.Itoa(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "renamed" || got.ID != id {
+		t.Errorf("unexpected case %+v", got)
+	}
+
+	if err := c.UpdateCaseStatus(ctx, strconv.Itoa(id), "CLOSED"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateCase(ctx, strconv.Itoa(id), thetalake.Case{Name: "renamed", Number: "CASE-1"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = c.GetCase(ctx, strconv.Itoa(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "CLOSED" || got.CloseDate == "" {
+		t.Errorf("closing the case was not kept across an update: %+v", got)
+	}
+
+	s.AddRecord(thetalake.Record{ID: "rec-1"})
+	if err := c.AddRecordToCase(ctx, strconv.Itoa(id), "rec-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.AddRecordToCase(ctx, strconv.Itoa(id), "missing"); !thetalake.IsNotFound(err) {
+		t.Errorf("expected a 404 for an unknown record, got %v", err)
+	}
+	if records := s.CaseRecords(id); len(records) != 1 || records[0] != "rec-1" {
+		t.Errorf("unexpected case records %v", records)
+	}
+	listed, err := c.GetCaseRecords(ctx, strconv.Itoa(id), thetalake.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(listed) != 1 || listed[0].ID != "rec-1" {
+		t.Errorf("unexpected listed records %+v", listed)
+	}
+	if err := c.RemoveRecordFromCase(ctx, strconv.Itoa(id), "rec-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteCase(ctx, strconv.Itoa(id)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCase(ctx, strconv.Itoa(id)); !thetalake.IsNotFound(err) {
+		t.Errorf("expected a 404 after delete, got %v", err)
+	}
+	if s.Len() != 0 {
+		t.Errorf("expected no objects left, got %d", s.Len())
+	}
+}
+
+func TestCaseMembers(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+	ctx := t.Context()
+
+	theCase, err := c.CreateCase(ctx, thetalake.Case{Name: "case", Number: "CASE-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := c.CreateUser(ctx, thetalake.User{Name: "jane", Email: "jane@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	caseID := strconv.Itoa(theCase.ID)
+
+	if _, err := c.CreateCaseMember(ctx, caseID, thetalake.CaseMember{UserID: user.ID, DirectoryGroupID: 1, Role: "VIEWER"}); err == nil {
+		t.Error("expected a member naming both a user and a group to be rejected")
+	}
+
+	member, err := c.CreateCaseMember(ctx, caseID, thetalake.CaseMember{UserID: user.ID, Role: "VIEWER"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreateCaseMember(ctx, caseID, thetalake.CaseMember{UserID: user.ID, Role: "EDITOR"}); !thetalake.IsConflict(err) {
+		t.Errorf("expected a 409 for a duplicate member, got %v", err)
+	}
+
+	memberID := strconv.Itoa(member.ID)
+	if _, err := c.UpdateCaseMember(ctx, caseID, memberID, thetalake.CaseMember{Role: "EDITOR"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.GetCaseMember(ctx, caseID, memberID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Role != "EDITOR" || got.UserID != user.ID || got.CaseID != theCase.ID {
+		t.Errorf("unexpected member %+v", got)
+	}
+	if _, err := c.GetCaseMember(ctx, "999", memberID); !thetalake.IsNotFound(err) {
+		t.Errorf("expected a 404 for a member of another case, got %v", err)
+	}
+
+	if err := c.DeleteCaseMember(ctx, caseID, memberID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCaseMember(ctx, caseID, memberID); !thetalake.IsNotFound(err) {
+		t.Errorf("expected a 404 after delete, got %v", err)
+	}
+}
+
+func TestUserPasswordsAreWriteOnly(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+
+	if _, err := c.CreateUser(t.Context(), thetalake.User{Name: "jane", Email: "jane@example.com", Password: "a", PasswordConfirmation: "b"}); err == nil {
+		t.Error("expected mismatched passwords to be rejected")
+	}
+
+	user, err := c.CreateUser(t.Context(), thetalake.User{Name: "jane", Email: "jane@example.com", Password: "a", PasswordConfirmation: "a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.Password != "" || user.PasswordConfirmation != "" {
+		t.Errorf("password returned by the API: %+v", user)
+	}
+
+	userID := strconv.Itoa(user.ID)
+	if err := c.SetUserPassword(t.Context(), userID, "c", "d"); err == nil {
+		t.Error("expected mismatched passwords to be rejected")
+	}
+	if err := c.SetUserPassword(t.Context(), userID, "c", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if n := s.PasswordChanges(user.ID); n != 1 {
+		t.Errorf("got %d password changes, want 1", n)
+	}
+}
+
+func TestUserActivation(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+	ctx := t.Context()
+
+	user, err := c.CreateUser(ctx, thetalake.User{Name: "jane", Email: "jane@example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !user.IsActive() {
+		t.Fatal("expected a new user to be active")
+	}
+
+	userID := strconv.Itoa(user.ID)
+	if err := c.DisableUser(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Updating the profile leaves the user disabled.
+	if _, err := c.UpdateUser(ctx, userID, thetalake.User{Name: "jane", Email: "jane.doe@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	if user, err = c.GetUser(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+	if user.IsActive() {
+		t.Error("expected the user to stay disabled")
+	}
+
+	if err := c.EnableUser(ctx, userID); err != nil {
+		t.Fatal(err)
+	}
+	if !s.UserActive(user.ID) {
+		t.Error("expected the user to be enabled")
+	}
+}
+
+func TestRoles(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+	ctx := t.Context()
+
+	s.AddRole(thetalake.Role{ID: 1, Name: "Administrator", Permissions: []string{"*"}})
+
+	if _, err := c.UpdateRole(ctx, "1", thetalake.Role{Name: "Administrator"}); err == nil {
+		t.Error("expected a built-in role to be read-only")
+	}
+
+	role, err := c.CreateRole(ctx, thetalake.Role{Name: "Reviewer", Permissions: []string{"records:review"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if role.BuiltIn {
+		t.Errorf("custom role reported as built-in: %+v", role)
+	}
+
+	roles, err := c.GetRoles(ctx, thetalake.RoleFilter{Name: "reviewer"}, thetalake.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(roles) != 1 || roles[0].ID != role.ID {
+		t.Errorf("unexpected roles %+v", roles)
+	}
+	if n := s.Len(); n != 1 {
+		t.Errorf("got Len %d, want only the custom role counted", n)
+	}
+}
+
+func TestSearchUsers(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+	ctx := t.Context()
+
+	var ids []int
+	for _, u := range []thetalake.User{
+		{Name: "jane", Email: "jane@example.com", RoleID: 2},
+		{Name: "john", Email: "john@example.com", RoleID: 1},
+		{Name: "joan", Email: "joan@example.org", RoleID: 2},
+	} {
+		user, err := c.CreateUser(ctx, u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, user.ID)
+	}
+	s.AddUserToDirectoryGroup(ids[0], 7)
+	s.AddUserToDirectoryGroup(ids[2], 7)
+	s.SetUserActive(ids[2], false)
+
+	active := true
+	users, err := c.SearchUsers(ctx, thetalake.UserFilter{RoleID: 2, DirectoryGroupID: 7, Active: &active}, thetalake.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].ID != ids[0] {
+		t.Errorf("unexpected users %+v", users)
+	}
+
+	users, err = c.SearchUsers(ctx, thetalake.UserFilter{EmailDomain: "example.org"}, thetalake.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].ID != ids[2] {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
+
+func TestPaging(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+
+	for i := range 25 {
+		s.AddAuditLogs(thetalake.AuditLog{ID: strconv.Itoa(i), Action: "login"})
+	}
+
+	logs, err := c.GetAuditLogs(t.Context(), thetalake.AuditLogFilter{}, thetalake.ListOptions{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 25 || logs[24].ID != "24" {
+		t.Errorf("unexpected audit logs %v", logs)
+	}
+}
+
+func TestAuthentication(t *testing.T) {
+	s := New(t)
+
+	c, _ := thetalake.NewClient(s.URL, "wrong")
+	c.MaxRetries = 0
+	if _, err := c.GetSystemStatus(t.Context()); !thetalake.IsUnauthorized(err) {
+		t.Errorf("expected a 401, got %v", err)
+	}
+
+	if status, err := newClient(t, s).GetSystemStatus(t.Context()); err != nil || status.Status != "ok" {
+		t.Errorf("unexpected status %+v, %v", status, err)
+	}
+}
+
+func TestIntegrationState(t *testing.T) {
+	s := New(t)
+	c := newClient(t, s)
+
+	state, err := c.UpdateIntegrationState(t.Context(), "789", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Paused {
+		t.Errorf("expected the integration to be paused: %+v", state)
+	}
+}
@@ -0,0 +1,210 @@
+package fakeapi
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// Records, analyses, policies, built-in roles and log entries are produced by
+// the platform rather than through the API, so tests seed them directly.
+//
+// Some helpers change objects that Terraform manages, as an administrator
+// would in the web interface, so tests can simulate drift introduced outside
+// Terraform.
+
+// AddRecord stores a record.
+func (s *Server) AddRecord(record thetalake.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[record.ID] = &record
+}
+
+// AddRole stores a built-in role.
+func (s *Server) AddRole(role thetalake.Role) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	role.BuiltIn = true
+	s.roles[role.ID] = &role
+}
+
+// AddAnalysis stores an analysis result.
+func (s *Server) AddAnalysis(analysis thetalake.Analysis) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.analyses[analysis.ID] = &analysis
+}
+
+// AddAnalysisPolicies appends analysis policies.
+func (s *Server) AddAnalysisPolicies(policies ...thetalake.AnalysisPolicy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.analysisPolicies = append(s.analysisPolicies, policies...)
+}
+
+// AddPolicyHits appends analysis policy hits.
+func (s *Server) AddPolicyHits(hits ...thetalake.PolicyHit) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.policyHits = append(s.policyHits, hits...)
+}
+
+// AddAuditLogs appends audit log entries.
+func (s *Server) AddAuditLogs(logs ...thetalake.AuditLog) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.auditLogs = append(s.auditLogs, logs...)
+}
+
+// AddEvents appends events.
+func (s *Server) AddEvents(events ...thetalake.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, events...)
+}
+
+// SetSystemStatus replaces the system status.
+func (s *Server) SetSystemStatus(status thetalake.SystemStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.systemStatus = status
+}
+
+// CaseStatus returns the status of a case, or "" if the case does not exist.
+func (s *Server) CaseStatus(caseID int) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.cases[caseID]; ok {
+		return c.Status
+	}
+
+	return ""
+}
+
+// SetCaseStatus opens or closes a case.
+func (s *Server) SetCaseStatus(caseID int, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.cases[caseID]; ok {
+		setCaseStatus(c, status)
+	}
+}
+
+// CaseRecords returns the sorted IDs of the records linked to a case.
+func (s *Server) CaseRecords(caseID int) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := []string{}
+	for id := range s.caseRecords[caseID] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// AddCaseRecord links a record to a case.
+func (s *Server) AddCaseRecord(caseID int, recordID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.caseRecords[caseID] == nil {
+		s.caseRecords[caseID] = map[string]bool{}
+	}
+	s.caseRecords[caseID][recordID] = true
+}
+
+// RemoveCaseRecord unlinks a record from a case.
+func (s *Server) RemoveCaseRecord(caseID int, recordID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.caseRecords[caseID], recordID)
+}
+
+// SetCaseMemberRole changes a case member's role.
+func (s *Server) SetCaseMemberRole(memberID int, role string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if m, ok := s.caseMembers[memberID]; ok {
+		m.Role = role
+	}
+}
+
+// RemoveCaseMember revokes a case member's access.
+func (s *Server) RemoveCaseMember(memberID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.caseMembers, memberID)
+}
+
+// PasswordChanges returns how many times a user's password was changed after
+// the user was created.
+func (s *Server) PasswordChanges(userID int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.passwordChanges[userID]
+}
+
+// UserActive reports whether a user exists and is active.
+func (s *Server) UserActive(userID int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	u, ok := s.users[userID]
+	return ok && u.IsActive()
+}
+
+// SetUserActive enables or disables a user.
+func (s *Server) SetUserActive(userID int, active bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, ok := s.users[userID]; ok {
+		u.Active = &active
+	}
+}
+
+// AddUserToDirectoryGroup adds a user to a directory group as the directory
+// sync would.
+func (s *Server) AddUserToDirectoryGroup(userID, groupID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if u, ok := s.users[userID]; ok && !slices.Contains(u.DirectoryGroupIDs, groupID) {
+		u.DirectoryGroupIDs = append(u.DirectoryGroupIDs, groupID)
+	}
+}
+
+// Len returns how many cases, case members, users, custom roles, groups,
+// policies, holds, tags and exports exist. Tests use it to check that destroy
+// removed everything.
+func (s *Server) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := len(s.cases) + len(s.caseMembers) + len(s.users) + len(s.directoryGroups) +
+		len(s.retentionPolicies) + len(s.legalHolds) + len(s.tags) + len(s.exports)
+	for _, role := range s.roles {
+		if !role.BuiltIn {
+			n++
+		}
+	}
+
+	return n
+}
@@ -0,0 +1,602 @@
+// Package mockapi provides a hand-written mock of thetalake.API for unit tests
+// that need to inject failures the fake API cannot produce.
+package mockapi
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// API implements thetalake.API. Each method calls the function in the field of
+// the same name with a Func suffix; methods whose field is nil fail with an
+// error. Every call is recorded in Calls.
+type API struct {
+	GetCaseFunc                 func(ctx context.Context, caseID string) (*thetalake.Case, error)
+	CreateCaseFunc              func(ctx context.Context, theCase thetalake.Case) (*thetalake.Case, error)
+	UpdateCaseFunc              func(ctx context.Context, caseID string, theCase thetalake.Case) (*thetalake.Case, error)
+	DeleteCaseFunc              func(ctx context.Context, caseID string) error
+	SearchCasesFunc             func(ctx context.Context, filter thetalake.CaseFilter, opts thetalake.ListOptions) ([]thetalake.Case, error)
+	UpdateCaseStatusFunc        func(ctx context.Context, caseID string, status string) error
+	AddRecordToCaseFunc         func(ctx context.Context, caseID string, recordID string) error
+	RemoveRecordFromCaseFunc    func(ctx context.Context, caseID string, recordID string) error
+	GetCaseRecordsFunc          func(ctx context.Context, caseID string, opts thetalake.ListOptions) ([]thetalake.Record, error)
+	GetCaseMemberFunc           func(ctx context.Context, caseID string, memberID string) (*thetalake.CaseMember, error)
+	CreateCaseMemberFunc        func(ctx context.Context, caseID string, member thetalake.CaseMember) (*thetalake.CaseMember, error)
+	UpdateCaseMemberFunc        func(ctx context.Context, caseID string, memberID string, member thetalake.CaseMember) (*thetalake.CaseMember, error)
+	DeleteCaseMemberFunc        func(ctx context.Context, caseID string, memberID string) error
+	GetUserFunc                 func(ctx context.Context, userID string) (*thetalake.User, error)
+	CreateUserFunc              func(ctx context.Context, user thetalake.User) (*thetalake.User, error)
+	UpdateUserFunc              func(ctx context.Context, userID string, user thetalake.User) (*thetalake.User, error)
+	DeleteUserFunc              func(ctx context.Context, userID string) error
+	SearchUsersFunc             func(ctx context.Context, filter thetalake.UserFilter, opts thetalake.ListOptions) ([]thetalake.User, error)
+	SetUserPasswordFunc         func(ctx context.Context, userID string, password string, passwordConfirmation string) error
+	EnableUserFunc              func(ctx context.Context, userID string) error
+	DisableUserFunc             func(ctx context.Context, userID string) error
+	GetRoleFunc                 func(ctx context.Context, roleID string) (*thetalake.Role, error)
+	CreateRoleFunc              func(ctx context.Context, role thetalake.Role) (*thetalake.Role, error)
+	UpdateRoleFunc              func(ctx context.Context, roleID string, role thetalake.Role) (*thetalake.Role, error)
+	DeleteRoleFunc              func(ctx context.Context, roleID string) error
+	GetRolesFunc                func(ctx context.Context, filter thetalake.RoleFilter, opts thetalake.ListOptions) ([]thetalake.Role, error)
+	GetDirectoryGroupFunc       func(ctx context.Context, groupID string) (*thetalake.DirectoryGroup, error)
+	CreateDirectoryGroupFunc    func(ctx context.Context, group thetalake.DirectoryGroup) (*thetalake.DirectoryGroup, error)
+	UpdateDirectoryGroupFunc    func(ctx context.Context, groupID string, group thetalake.DirectoryGroup) (*thetalake.DirectoryGroup, error)
+	DeleteDirectoryGroupFunc    func(ctx context.Context, groupID string) error
+	GetRetentionPolicyFunc      func(ctx context.Context, policyID string) (*thetalake.RetentionPolicy, error)
+	CreateRetentionPolicyFunc   func(ctx context.Context, policy thetalake.RetentionPolicy) (*thetalake.RetentionPolicy, error)
+	UpdateRetentionPolicyFunc   func(ctx context.Context, policyID string, policy thetalake.RetentionPolicy) (*thetalake.RetentionPolicy, error)
+	DeleteRetentionPolicyFunc   func(ctx context.Context, policyID string) error
+	GetLegalHoldFunc            func(ctx context.Context, holdID string) (*thetalake.LegalHold, error)
+	CreateLegalHoldFunc         func(ctx context.Context, hold thetalake.LegalHold) (*thetalake.LegalHold, error)
+	UpdateLegalHoldFunc         func(ctx context.Context, holdID string, hold thetalake.LegalHold) (*thetalake.LegalHold, error)
+	DeleteLegalHoldFunc         func(ctx context.Context, holdID string) error
+	GetTagFunc                  func(ctx context.Context, tagID string) (*thetalake.Tag, error)
+	CreateTagFunc               func(ctx context.Context, tag thetalake.Tag) (*thetalake.Tag, error)
+	UpdateTagFunc               func(ctx context.Context, tagID string, tag thetalake.Tag) (*thetalake.Tag, error)
+	DeleteTagFunc               func(ctx context.Context, tagID string) error
+	GetExportFunc               func(ctx context.Context, exportID string) (*thetalake.Export, error)
+	CreateExportFunc            func(ctx context.Context, export thetalake.Export) (*thetalake.Export, error)
+	DeleteExportFunc            func(ctx context.Context, exportID string) error
+	GetRecordFunc               func(ctx context.Context, recordID string) (*thetalake.Record, error)
+	UpdateRecordReviewStateFunc func(ctx context.Context, recordID string, reviewState string, comment string) (*thetalake.Record, error)
+	GetIntegrationStateFunc     func(ctx context.Context, integrationID string) (*thetalake.IntegrationState, error)
+	UpdateIntegrationStateFunc  func(ctx context.Context, integrationID string, paused bool) (*thetalake.IntegrationState, error)
+	GetAnalysisFunc             func(ctx context.Context, analysisID string) (*thetalake.Analysis, error)
+	GetAnalysisPoliciesFunc     func(ctx context.Context) ([]thetalake.AnalysisPolicy, error)
+	GetAnalysisPolicyHitsFunc   func(ctx context.Context, opts thetalake.ListOptions) ([]thetalake.PolicyHit, error)
+	GetAuditLogsFunc            func(ctx context.Context, filter thetalake.AuditLogFilter, opts thetalake.ListOptions) ([]thetalake.AuditLog, error)
+	GetEventsFunc               func(ctx context.Context, opts thetalake.ListOptions) ([]thetalake.Event, error)
+	GetSystemStatusFunc         func(ctx context.Context) (*thetalake.SystemStatus, error)
+
+	mu    sync.Mutex
+	calls []string
+}
+
+var _ thetalake.API = (*API)(nil)
+
+// Calls returns the names of the methods called so far, in order.
+func (m *API) Calls() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]string(nil), m.calls...)
+}
+
+// Called reports whether the named method was called.
+func (m *API) Called(method string) bool {
+	for _, c := range m.Calls() {
+		if c == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *API) record(method string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, method)
+}
+
+func notImplemented(method string) error {
+	return fmt.Errorf("mockapi: %s not implemented", method)
+}
+
+func (m *API) GetCase(ctx context.Context, caseID string) (*thetalake.Case, error) {
+	m.record("GetCase")
+	if m.GetCaseFunc == nil {
+		return nil, notImplemented("GetCase")
+	}
+
+	return m.GetCaseFunc(ctx, caseID)
+}
+
+func (m *API) CreateCase(ctx context.Context, theCase thetalake.Case) (*thetalake.Case, error) {
+	m.record("CreateCase")
+	if m.CreateCaseFunc == nil {
+		return nil, notImplemented("CreateCase")
+	}
+
+	return m.CreateCaseFunc(ctx, theCase)
+}
+
+func (m *API) UpdateCase(ctx context.Context, caseID string, theCase thetalake.Case) (*thetalake.Case, error) {
+	m.record("UpdateCase")
+	if m.UpdateCaseFunc == nil {
+		return nil, notImplemented("UpdateCase")
+	}
+
+	return m.UpdateCaseFunc(ctx, caseID, theCase)
+}
+
+func (m *API) DeleteCase(ctx context.Context, caseID string) error {
+	m.record("DeleteCase")
+	if m.DeleteCaseFunc == nil {
+		return notImplemented("DeleteCase")
+	}
+
+	return m.DeleteCaseFunc(ctx, caseID)
+}
+
+func (m *API) SearchCases(ctx context.Context, filter thetalake.CaseFilter, opts thetalake.ListOptions) ([]thetalake.Case, error) {
+	m.record("SearchCases")
+	if m.SearchCasesFunc == nil {
+		return nil, notImplemented("SearchCases")
+	}
+
+	return m.SearchCasesFunc(ctx, filter, opts)
+}
+
+func (m *API) UpdateCaseStatus(ctx context.Context, caseID string, status string) error {
+	m.record("UpdateCaseStatus")
+	if m.UpdateCaseStatusFunc == nil {
+		return notImplemented("UpdateCaseStatus")
+	}
+
+	return m.UpdateCaseStatusFunc(ctx, caseID, status)
+}
+
+func (m *API) AddRecordToCase(ctx context.Context, caseID string, recordID string) error {
+	m.record("AddRecordToCase")
+	if m.AddRecordToCaseFunc == nil {
+		return notImplemented("AddRecordToCase")
+	}
+
+	return m.AddRecordToCaseFunc(ctx, caseID, recordID)
+}
+
+func (m *API) RemoveRecordFromCase(ctx context.Context, caseID string, recordID string) error {
+	m.record("RemoveRecordFromCase")
+	if m.RemoveRecordFromCaseFunc == nil {
+		return notImplemented("RemoveRecordFromCase")
+	}
+
+	return m.RemoveRecordFromCaseFunc(ctx, caseID, recordID)
+}
+
+func (m *API) GetCaseRecords(ctx context.Context, caseID string, opts thetalake.ListOptions) ([]thetalake.Record, error) {
+	m.record("GetCaseRecords")
+	if m.GetCaseRecordsFunc == nil {
+		return nil, notImplemented("GetCaseRecords")
+	}
+
+	return m.GetCaseRecordsFunc(ctx, caseID, opts)
+}
+
+func (m *API) GetCaseMember(ctx context.Context, caseID string, memberID string) (*thetalake.CaseMember, error) {
+	m.record("GetCaseMember")
+	if m.GetCaseMemberFunc == nil {
+		return nil, notImplemented("GetCaseMember")
+	}
+
+	return m.GetCaseMemberFunc(ctx, caseID, memberID)
+}
+
+func (m *API) CreateCaseMember(ctx context.Context, caseID string, member thetalake.CaseMember) (*thetalake.CaseMember, error) {
+	m.record("CreateCaseMember")
+	if m.CreateCaseMemberFunc == nil {
+		return nil, notImplemented("CreateCaseMember")
+	}
+
+	return m.CreateCaseMemberFunc(ctx, caseID, member)
+}
+
+func (m *API) UpdateCaseMember(ctx context.Context, caseID string, memberID string, member thetalake.CaseMember) (*thetalake.CaseMember, error) {
+	m.record("UpdateCaseMember")
+	if m.UpdateCaseMemberFunc == nil {
+		return nil, notImplemented("UpdateCaseMember")
+	}
+
+	return m.UpdateCaseMemberFunc(ctx, caseID, memberID, member)
+}
+
+func (m *API) DeleteCaseMember(ctx context.Context, caseID string, memberID string) error {
+	m.record("DeleteCaseMember")
+	if m.DeleteCaseMemberFunc == nil {
+		return notImplemented("DeleteCaseMember")
+	}
+
+	return m.DeleteCaseMemberFunc(ctx, caseID, memberID)
+}
+
+func (m *API) GetUser(ctx context.Context, userID string) (*thetalake.User, error) {
+	m.record("GetUser")
+	if m.GetUserFunc == nil {
+		return nil, notImplemented("GetUser")
+	}
+
+	return m.GetUserFunc(ctx, userID)
+}
+
+func (m *API) CreateUser(ctx context.Context, user thetalake.User) (*thetalake.User, error) {
+	m.record("CreateUser")
+	if m.CreateUserFunc == nil {
+		return nil, notImplemented("CreateUser")
+	}
+
+	return m.CreateUserFunc(ctx, user)
+}
+
+func (m *API) UpdateUser(ctx context.Context, userID string, user thetalake.User) (*thetalake.User, error) {
+	m.record("UpdateUser")
+	if m.UpdateUserFunc == nil {
+		return nil, notImplemented("UpdateUser")
+	}
+
+	return m.UpdateUserFunc(ctx, userID, user)
+}
+
+func (m *API) DeleteUser(ctx context.Context, userID string) error {
+	m.record("DeleteUser")
+	if m.DeleteUserFunc == nil {
+		return notImplemented("DeleteUser")
+	}
+
+	return m.DeleteUserFunc(ctx, userID)
+}
+
+func (m *API) SearchUsers(ctx context.Context, filter thetalake.UserFilter, opts thetalake.ListOptions) ([]thetalake.User, error) {
+	m.record("SearchUsers")
+	if m.SearchUsersFunc == nil {
+		return nil, notImplemented("SearchUsers")
+	}
+
+	return m.SearchUsersFunc(ctx, filter, opts)
+}
+
+func (m *API) SetUserPassword(ctx context.Context, userID string, password string, passwordConfirmation string) error {
+	m.record("SetUserPassword")
+	if m.SetUserPasswordFunc == nil {
+		return notImplemented("SetUserPassword")
+	}
+
+	return m.SetUserPasswordFunc(ctx, userID, password, passwordConfirmation)
+}
+
+func (m *API) EnableUser(ctx context.Context, userID string) error {
+	m.record("EnableUser")
+	if m.EnableUserFunc == nil {
+		return notImplemented("EnableUser")
+	}
+
+	return m.EnableUserFunc(ctx, userID)
+}
+
+func (m *API) DisableUser(ctx context.Context, userID string) error {
+	m.record("DisableUser")
+	if m.DisableUserFunc == nil {
+		return notImplemented("DisableUser")
+	}
+
+	return m.DisableUserFunc(ctx, userID)
+}
+
+func (m *API) GetRole(ctx context.Context, roleID string) (*thetalake.Role, error) {
+	m.record("GetRole")
+	if m.GetRoleFunc == nil {
+		return nil, notImplemented("GetRole")
+	}
+
+	return m.GetRoleFunc(ctx, roleID)
+}
+
+func (m *API) CreateRole(ctx context.Context, role thetalake.Role) (*thetalake.Role, error) {
+	m.record("CreateRole")
+	if m.CreateRoleFunc == nil {
+		return nil, notImplemented("CreateRole")
+	}
+
+	return m.CreateRoleFunc(ctx, role)
+}
+
+func (m *API) UpdateRole(ctx context.Context, roleID string, role thetalake.Role) (*thetalake.Role, error) {
+	m.record("UpdateRole")
+	if m.UpdateRoleFunc == nil {
+		return nil, notImplemented("UpdateRole")
+	}
+
+	return m.UpdateRoleFunc(ctx, roleID, role)
+}
+
+func (m *API) DeleteRole(ctx context.Context, roleID string) error {
+	m.record("DeleteRole")
+	if m.DeleteRoleFunc == nil {
+		return notImplemented("DeleteRole")
+	}
+
+	return m.DeleteRoleFunc(ctx, roleID)
+}
+
+func (m *API) GetRoles(ctx context.Context, filter thetalake.RoleFilter, opts thetalake.ListOptions) ([]thetalake.Role, error) {
+	m.record("GetRoles")
+	if m.GetRolesFunc == nil {
+		return nil, notImplemented("GetRoles")
+	}
+
+	return m.GetRolesFunc(ctx, filter, opts)
+}
+
+func (m *API) GetDirectoryGroup(ctx context.Context, groupID string) (*thetalake.DirectoryGroup, error) {
+	m.record("GetDirectoryGroup")
+	if m.GetDirectoryGroupFunc == nil {
+		return nil, notImplemented("GetDirectoryGroup")
+	}
+
+	return m.GetDirectoryGroupFunc(ctx, groupID)
+}
+
+func (m *API) CreateDirectoryGroup(ctx context.Context, group thetalake.DirectoryGroup) (*thetalake.DirectoryGroup, error) {
+	m.record("CreateDirectoryGroup")
+	if m.CreateDirectoryGroupFunc == nil {
+		return nil, notImplemented("CreateDirectoryGroup")
+	}
+
+	return m.CreateDirectoryGroupFunc(ctx, group)
+}
+
+func (m *API) UpdateDirectoryGroup(ctx context.Context, groupID string, group thetalake.DirectoryGroup) (*thetalake.DirectoryGroup, error) {
+	m.record("UpdateDirectoryGroup")
+	if m.UpdateDirectoryGroupFunc == nil {
+		return nil, notImplemented("UpdateDirectoryGroup")
+	}
+
+	return m.UpdateDirectoryGroupFunc(ctx, groupID, group)
+}
+
+func (m *API) DeleteDirectoryGroup(ctx context.Context, groupID string) error {
+	m.record("DeleteDirectoryGroup")
+	if m.DeleteDirectoryGroupFunc == nil {
+		return notImplemented("DeleteDirectoryGroup")
+	}
+
+	return m.DeleteDirectoryGroupFunc(ctx, groupID)
+}
+
+func (m *API) GetRetentionPolicy(ctx context.Context, policyID string) (*thetalake.RetentionPolicy, error) {
+	m.record("GetRetentionPolicy")
+	if m.GetRetentionPolicyFunc == nil {
+		return nil, notImplemented("GetRetentionPolicy")
+	}
+
+	return m.GetRetentionPolicyFunc(ctx, policyID)
+}
+
+func (m *API) CreateRetentionPolicy(ctx context.Context, policy thetalake.RetentionPolicy) (*thetalake.RetentionPolicy, error) {
+	m.record("CreateRetentionPolicy")
+	if m.CreateRetentionPolicyFunc == nil {
+		return nil, notImplemented("CreateRetentionPolicy")
+	}
+
+	return m.CreateRetentionPolicyFunc(ctx, policy)
+}
+
+func (m *API) UpdateRetentionPolicy(ctx context.Context, policyID string, policy thetalake.RetentionPolicy) (*thetalake.RetentionPolicy, error) {
+	m.record("UpdateRetentionPolicy")
+	if m.UpdateRetentionPolicyFunc == nil {
+		return nil, notImplemented("UpdateRetentionPolicy")
+	}
+
+	return m.UpdateRetentionPolicyFunc(ctx, policyID, policy)
+}
+
+func (m *API) DeleteRetentionPolicy(ctx context.Context, policyID string) error {
+	m.record("DeleteRetentionPolicy")
+	if m.DeleteRetentionPolicyFunc == nil {
+		return notImplemented("DeleteRetentionPolicy")
+	}
+
+	return m.DeleteRetentionPolicyFunc(ctx, policyID)
+}
+
+func (m *API) GetLegalHold(ctx context.Context, holdID string) (*thetalake.LegalHold, error) {
+	m.record("GetLegalHold")
+	if m.GetLegalHoldFunc == nil {
+		return nil, notImplemented("GetLegalHold")
+	}
+
+	return m.GetLegalHoldFunc(ctx, holdID)
+}
+
+func (m *API) CreateLegalHold(ctx context.Context, hold thetalake.LegalHold) (*thetalake.LegalHold, error) {
+	m.record("CreateLegalHold")
+	if m.CreateLegalHoldFunc == nil {
+		return nil, notImplemented("CreateLegalHold")
+	}
+
+	return m.CreateLegalHoldFunc(ctx, hold)
+}
+
+func (m *API) UpdateLegalHold(ctx context.Context, holdID string, hold thetalake.LegalHold) (*thetalake.LegalHold, error) {
+	m.record("UpdateLegalHold")
+	if m.UpdateLegalHoldFunc == nil {
+		return nil, notImplemented("UpdateLegalHold")
+	}
+
+	return m.UpdateLegalHoldFunc(ctx, holdID, hold)
+}
+
+func (m *API) DeleteLegalHold(ctx context.Context, holdID string) error {
+	m.record("DeleteLegalHold")
+	if m.DeleteLegalHoldFunc == nil {
+		return notImplemented("DeleteLegalHold")
+	}
+
+	return m.DeleteLegalHoldFunc(ctx, holdID)
+}
+
+func (m *API) GetTag(ctx context.Context, tagID string) (*thetalake.Tag, error) {
+	m.record("GetTag")
+	if m.GetTagFunc == nil {
+		return nil, notImplemented("GetTag")
+	}
+
+	return m.GetTagFunc(ctx, tagID)
+}
+
+func (m *API) CreateTag(ctx context.Context, tag thetalake.Tag) (*thetalake.Tag, error) {
+	m.record("CreateTag")
+	if m.CreateTagFunc == nil {
+		return nil, notImplemented("CreateTag")
+	}
+
+	return m.CreateTagFunc(ctx, tag)
+}
+
+func (m *API) UpdateTag(ctx context.Context, tagID string, tag thetalake.Tag) (*thetalake.Tag, error) {
+	m.record("UpdateTag")
+	if m.UpdateTagFunc == nil {
+		return nil, notImplemented("UpdateTag")
+	}
+
+	return m.UpdateTagFunc(ctx, tagID, tag)
+}
+
+func (m *API) DeleteTag(ctx context.Context, tagID string) error {
+	m.record("DeleteTag")
+	if m.DeleteTagFunc == nil {
+		return notImplemented("DeleteTag")
+	}
+
+	return m.DeleteTagFunc(ctx, tagID)
+}
+
+func (m *API) GetExport(ctx context.Context, exportID string) (*thetalake.Export, error) {
+	m.record("GetExport")
+	if m.GetExportFunc == nil {
+		return nil, notImplemented("GetExport")
+	}
+
+	return m.GetExportFunc(ctx, exportID)
+}
+
+func (m *API) CreateExport(ctx context.Context, export thetalake.Export) (*thetalake.Export, error) {
+	m.record("CreateExport")
+	if m.CreateExportFunc == nil {
+		return nil, notImplemented("CreateExport")
+	}
+
+	return m.CreateExportFunc(ctx, export)
+}
+
+func (m *API) DeleteExport(ctx context.Context, exportID string) error {
+	m.record("DeleteExport")
+	if m.DeleteExportFunc == nil {
+		return notImplemented("DeleteExport")
+	}
+
+	return m.DeleteExportFunc(ctx, exportID)
+}
+
+func (m *API) GetRecord(ctx context.Context, recordID string) (*thetalake.Record, error) {
+	m.record("GetRecord")
+	if m.GetRecordFunc == nil {
+		return nil, notImplemented("GetRecord")
+	}
+
+	return m.GetRecordFunc(ctx, recordID)
+}
+
+func (m *API) UpdateRecordReviewState(ctx context.Context, recordID string, reviewState string, comment string) (*thetalake.Record, error) {
+	m.record("UpdateRecordReviewState")
+	if m.UpdateRecordReviewStateFunc == nil {
+		return nil, notImplemented("UpdateRecordReviewState")
+	}
+
+	return m.UpdateRecordReviewStateFunc(ctx, recordID, reviewState, comment)
+}
+
+func (m *API) GetIntegrationState(ctx context.Context, integrationID string) (*thetalake.IntegrationState, error) {
+	m.record("GetIntegrationState")
+	if m.GetIntegrationStateFunc == nil {
+		return nil, notImplemented("GetIntegrationState")
+	}
+
+	return m.GetIntegrationStateFunc(ctx, integrationID)
+}
+
+func (m *API) UpdateIntegrationState(ctx context.Context, integrationID string, paused bool) (*thetalake.IntegrationState, error) {
+	m.record("UpdateIntegrationState")
+	if m.UpdateIntegrationStateFunc == nil {
+		return nil, notImplemented("UpdateIntegrationState")
+	}
+
+	return m.UpdateIntegrationStateFunc(ctx, integrationID, paused)
+}
+
+func (m *API) GetAnalysis(ctx context.Context, analysisID string) (*thetalake.Analysis, error) {
+	m.record("GetAnalysis")
+	if m.GetAnalysisFunc == nil {
+		return nil, notImplemented("GetAnalysis")
+	}
+
+	return m.GetAnalysisFunc(ctx, analysisID)
+}
+
+func (m *API) GetAnalysisPolicies(ctx context.Context) ([]thetalake.AnalysisPolicy, error) {
+	m.record("GetAnalysisPolicies")
+	if m.GetAnalysisPoliciesFunc == nil {
+		return nil, notImplemented("GetAnalysisPolicies")
+	}
+
+	return m.GetAnalysisPoliciesFunc(ctx)
+}
+
+func (m *API) GetAnalysisPolicyHits(ctx context.Context, opts thetalake.ListOptions) ([]thetalake.PolicyHit, error) {
+	m.record("GetAnalysisPolicyHits")
+	if m.GetAnalysisPolicyHitsFunc == nil {
+		return nil, notImplemented("GetAnalysisPolicyHits")
+	}
+
+	return m.GetAnalysisPolicyHitsFunc(ctx, opts)
+}
+
+func (m *API) GetAuditLogs(ctx context.Context, filter thetalake.AuditLogFilter, opts thetalake.ListOptions) ([]thetalake.AuditLog, error) {
+	m.record("GetAuditLogs")
+	if m.GetAuditLogsFunc == nil {
+		return nil, notImplemented("GetAuditLogs")
+	}
+
+	return m.GetAuditLogsFunc(ctx, filter, opts)
+}
+
+func (m *API) GetEvents(ctx context.Context, opts thetalake.ListOptions) ([]thetalake.Event, error) {
+	m.record("GetEvents")
+	if m.GetEventsFunc == nil {
+		return nil, notImplemented("GetEvents")
+	}
+
+	return m.GetEventsFunc(ctx, opts)
+}
+
+func (m *API) GetSystemStatus(ctx context.Context) (*thetalake.SystemStatus, error) {
+	m.record("GetSystemStatus")
+	if m.GetSystemStatusFunc == nil {
+		return nil, notImplemented("GetSystemStatus")
+	}
+
+	return m.GetSystemStatusFunc(ctx)
+}
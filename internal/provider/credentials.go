@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Environment variables read by the provider when an attribute is not set in
+// the configuration.
+const (
+	envEndpoint        = "THETALAKE_ENDPOINT"
+	envToken           = "THETALAKE_TOKEN"
+	envProfile         = "THETALAKE_PROFILE"
+	envCredentialsFile = "THETALAKE_CREDENTIALS_FILE"
+	envClientID        = "THETALAKE_CLIENT_ID"
+	envClientSecret    = "THETALAKE_CLIENT_SECRET"
+	envTokenURL        = "THETALAKE_TOKEN_URL"
+)
+
+const defaultProfile = "default"
+
+// defaultCredentialsFile returns ~/.thetalake/credentials, or an empty string
+// when the home directory cannot be determined.
+func defaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(home, ".thetalake", "credentials")
+}
+
+// loadProfile reads the named profile from an INI-style credentials file:
+//
+//	[default]
+//	endpoint = https://api.thetalake.ai/api/v1
+//	token    = ...
+//
+// When required is false, a missing file or profile yields an empty profile
+// instead of an error.
+func loadProfile(file, name string, required bool) (map[string]string, error) {
+	profiles, err := parseCredentialsFile(file)
+	if errors.Is(err, fs.ErrNotExist) && !required {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	profile, ok := profiles[name]
+	if !ok {
+		if required {
+			return nil, fmt.Errorf("profile %q not found in %s", name, file)
+		}
+		return map[string]string{}, nil
+	}
+
+	return profile, nil
+}
+
+func parseCredentialsFile(file string) (map[string]map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	profiles := map[string]map[string]string{}
+	var current map[string]string
+
+	scanner := bufio.NewScanner(f)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";"):
+			continue
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			current = profiles[name]
+			if current == nil {
+				current = map[string]string{}
+				profiles[name] = current
+			}
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok || current == nil {
+				return nil, fmt.Errorf("%s:%d: expected a [profile] header or a key = value pair", file, lineNo)
+			}
+			current[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"'`)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// resolveSetting returns the first non-empty value from the configuration
+// attribute, the environment variable and the profile, in that order.
+func resolveSetting(attr types.String, envVar string, profile map[string]string, key string) string {
+	if v := attr.ValueString(); v != "" {
+		return v
+	}
+
+	if v := os.Getenv(envVar); v != "" {
+		return v
+	}
+
+	return profile[key]
+}
+
+// authSource records which authentication settings one configuration source
+// provides.
+type authSource struct {
+	name   string
+	token  bool
+	oauth2 bool
+}
+
+// chooseOAuth2 reports whether to authenticate with OAuth2 client credentials
+// rather than a static token. Sources are given from highest to lowest
+// precedence and the first one with any authentication setting decides, so a
+// token in the provider block wins over client credentials in the environment.
+// A source that sets both is an error.
+func chooseOAuth2(sources ...authSource) (bool, error) {
+	for _, source := range sources {
+		if source.token && source.oauth2 {
+			return false, fmt.Errorf("%s sets both a token and OAuth2 client credentials; configure only one of them", source.name)
+		}
+		if source.token || source.oauth2 {
+			return source.oauth2, nil
+		}
+	}
+
+	return false, nil
+}
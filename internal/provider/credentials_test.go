@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/fakeapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func writeCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func TestLoadProfile(t *testing.T) {
+	file := writeCredentialsFile(t, `
+# Theta Lake credentials
+[default]
+endpoint = https://api.thetalake.ai/api/v1
+token    = "default-token"
+
+[staging]
+endpoint = https://staging.example.com/api/v1
+; tokens may be quoted or not
+token = staging-token
+`)
+
+	profile, err := loadProfile(file, "staging", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if profile["token"] != "staging-token" || profile["endpoint"] != "https://staging.example.com/api/v1" {
+		t.Errorf("unexpected profile %v", profile)
+	}
+
+	profile, err = loadProfile(file, "default", true)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if profile["token"] != "default-token" {
+		t.Errorf("quotes were not stripped: %q", profile["token"])
+	}
+
+	if _, err := loadProfile(file, "missing", true); err == nil {
+		t.Error("expected an error for a missing required profile")
+	}
+	if profile, err := loadProfile(file, "missing", false); err != nil || len(profile) != 0 {
+		t.Errorf("an optional missing profile should be empty, got %v, %v", profile, err)
+	}
+	if _, err := loadProfile(filepath.Join(t.TempDir(), "nope"), "default", false); err != nil {
+		t.Errorf("an optional missing file should not fail: %s", err)
+	}
+	if _, err := loadProfile(filepath.Join(t.TempDir(), "nope"), "prod", true); err == nil {
+		t.Error("expected an error for a missing file with a required profile")
+	}
+}
+
+func TestLoadProfileSyntaxError(t *testing.T) {
+	file := writeCredentialsFile(t, "token = outside-any-profile\n")
+
+	if _, err := loadProfile(file, "default", false); err == nil {
+		t.Error("expected a syntax error")
+	}
+}
+
+func TestResolveSettingPrecedence(t *testing.T) {
+	profile := map[string]string{"token": "from-profile"}
+
+	t.Setenv(envToken, "")
+	if got := resolveSetting(types.StringNull(), envToken, profile, "token"); got != "from-profile" {
+		t.Errorf("expected the profile value, got %q", got)
+	}
+
+	t.Setenv(envToken, "from-env")
+	if got := resolveSetting(types.StringNull(), envToken, profile, "token"); got != "from-env" {
+		t.Errorf("expected the environment value, got %q", got)
+	}
+
+	if got := resolveSetting(types.StringValue("from-hcl"), envToken, profile, "token"); got != "from-hcl" {
+		t.Errorf("expected the configuration value, got %q", got)
+	}
+}
+
+func TestProviderConfigureAuthPrecedence(t *testing.T) {
+	server := fakeapi.New(t)
+
+	tests := []struct {
+		name      string
+		token     types.String
+		oauth2    *OAuth2Model
+		env       map[string]string
+		profile   string
+		wantError string
+	}{
+		{
+			name:  "configuration token beats environment client credentials",
+			token: types.StringValue(fakeapi.Token),
+			env:   map[string]string{envClientID: "client", envClientSecret: "s3cret"},
+		},
+		{
+			name:    "configuration token beats profile client credentials",
+			token:   types.StringValue(fakeapi.Token),
+			profile: "[default]\nclient_id = client\n",
+		},
+		{
+			name:      "environment client credentials beat profile token",
+			env:       map[string]string{envClientID: "client"},
+			profile:   "[default]\ntoken = " + fakeapi.Token + "\n",
+			wantError: "Incomplete OAuth2 Configuration",
+		},
+		{
+			name:      "token and oauth2 block in the configuration",
+			token:     types.StringValue(fakeapi.Token),
+			oauth2:    &OAuth2Model{ClientID: types.StringValue("client"), Scopes: types.ListNull(types.StringType)},
+			wantError: "Conflicting Authentication Settings",
+		},
+		{
+			name:      "token and client ID in the environment",
+			env:       map[string]string{envToken: fakeapi.Token, envClientID: "client"},
+			wantError: "Conflicting Authentication Settings",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+
+			for _, name := range []string{envEndpoint, envToken, envProfile, envClientID, envClientSecret, envTokenURL} {
+				t.Setenv(name, tt.env[name])
+			}
+			t.Setenv(envCredentialsFile, filepath.Join(t.TempDir(), "missing"))
+			if tt.profile != "" {
+				t.Setenv(envCredentialsFile, writeCredentialsFile(t, tt.profile))
+			}
+
+			p := New()
+			var schemaResp provider.SchemaResponse
+			p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+			// Config has no setter, so build the value through a state.
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			diags := state.Set(ctx, &ThetaLakeProviderModel{
+				Endpoint: types.StringValue(server.URL),
+				Token:    tt.token,
+				OAuth2:   tt.oauth2,
+			})
+			if diags.HasError() {
+				t.Fatalf("building config: %v", diags)
+			}
+
+			var resp provider.ConfigureResponse
+			p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+
+			if tt.wantError != "" {
+				if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != tt.wantError {
+					t.Fatalf("expected %q, got %v", tt.wantError, resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+
+			// The fake API only accepts its static token.
+			if _, err := resp.ResourceData.(thetalake.API).GetSystemStatus(ctx); err != nil {
+				t.Errorf("expected token authentication, got error: %s", err)
+			}
+		})
+	}
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +19,7 @@ func NewAnalysisDataSource() datasource.DataSource {
 
 // AnalysisDataSource defines the data source implementation.
 type AnalysisDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // AnalysisDataSourceModel describes the data source data model.
@@ -74,12 +74,12 @@ func (d *AnalysisDataSource) Configure(ctx context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -97,7 +97,7 @@ func (d *AnalysisDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	analysis, err := d.client.GetAnalysis(data.ID.ValueString())
+	analysis, err := d.client.GetAnalysis(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read analysis, got error: %s", err))
 		return
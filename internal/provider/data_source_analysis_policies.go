@@ -8,7 +8,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -20,7 +20,7 @@ func NewAnalysisPoliciesDataSource() datasource.DataSource {
 
 // AnalysisPoliciesDataSource defines the data source implementation.
 type AnalysisPoliciesDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // AnalysisPoliciesDataSourceModel describes the data source data model.
@@ -80,12 +80,12 @@ func (d *AnalysisPoliciesDataSource) Configure(ctx context.Context, req datasour
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -97,7 +97,7 @@ func (d *AnalysisPoliciesDataSource) Configure(ctx context.Context, req datasour
 func (d *AnalysisPoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data AnalysisPoliciesDataSourceModel
 
-	policies, err := d.client.GetAnalysisPolicies()
+	policies, err := d.client.GetAnalysisPolicies(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read analysis policies, got error: %s", err))
 		return
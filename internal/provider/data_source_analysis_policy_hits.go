@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,12 +19,14 @@ func NewAnalysisPolicyHitsDataSource() datasource.DataSource {
 
 // AnalysisPolicyHitsDataSource defines the data source implementation.
 type AnalysisPolicyHitsDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // AnalysisPolicyHitsDataSourceModel describes the data source data model.
 type AnalysisPolicyHitsDataSourceModel struct {
-	Hits []PolicyHitModel `tfsdk:"hits"`
+	MaxResults types.Int64      `tfsdk:"max_results"`
+	PageSize   types.Int64      `tfsdk:"page_size"`
+	Hits       []PolicyHitModel `tfsdk:"hits"`
 }
 
 type PolicyHitModel struct {
@@ -44,6 +46,8 @@ func (d *AnalysisPolicyHitsDataSource) Schema(ctx context.Context, req datasourc
 		MarkdownDescription: "Theta Lake Analysis Policy Hits Data Source",
 
 		Attributes: map[string]schema.Attribute{
+			"max_results": maxResultsAttribute(),
+			"page_size":   pageSizeAttribute(),
 			"hits": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -75,12 +79,12 @@ func (d *AnalysisPolicyHitsDataSource) Configure(ctx context.Context, req dataso
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -92,7 +96,16 @@ func (d *AnalysisPolicyHitsDataSource) Configure(ctx context.Context, req dataso
 func (d *AnalysisPolicyHitsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data AnalysisPolicyHitsDataSourceModel
 
-	hits, err := d.client.GetAnalysisPolicyHits()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	opts, diags := listOptions(data.MaxResults, data.PageSize)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hits, err := d.client.GetAnalysisPolicyHits(ctx, opts)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read analysis policy hits, got error: %s", err))
 		return
@@ -3,11 +3,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,12 +21,19 @@ func NewAuditLogsDataSource() datasource.DataSource {
 
 // AuditLogsDataSource defines the data source implementation.
 type AuditLogsDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // AuditLogsDataSourceModel describes the data source data model.
 type AuditLogsDataSourceModel struct {
-	Logs []AuditLogModel `tfsdk:"logs"`
+	User       types.String    `tfsdk:"user"`
+	Action     types.String    `tfsdk:"action"`
+	Resource   types.String    `tfsdk:"resource"`
+	Since      types.String    `tfsdk:"since"`
+	Until      types.String    `tfsdk:"until"`
+	MaxResults types.Int64     `tfsdk:"max_results"`
+	PageSize   types.Int64     `tfsdk:"page_size"`
+	Logs       []AuditLogModel `tfsdk:"logs"`
 }
 
 type AuditLogModel struct {
@@ -44,6 +53,28 @@ func (d *AuditLogsDataSource) Schema(ctx context.Context, req datasource.SchemaR
 		MarkdownDescription: "Theta Lake Audit Logs Data Source",
 
 		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return entries performed by this user.",
+			},
+			"action": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return entries for this action.",
+			},
+			"resource": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return entries affecting this resource.",
+			},
+			"since": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return entries at or after this time. Either an RFC 3339 timestamp or a duration such as `24h`, counted back from now.",
+			},
+			"until": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return entries at or before this time. Either an RFC 3339 timestamp or a duration such as `1h`, counted back from now.",
+			},
+			"max_results": maxResultsAttribute(),
+			"page_size":   pageSizeAttribute(),
 			"logs": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -75,12 +106,12 @@ func (d *AuditLogsDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -92,7 +123,40 @@ func (d *AuditLogsDataSource) Configure(ctx context.Context, req datasource.Conf
 func (d *AuditLogsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data AuditLogsDataSourceModel
 
-	logs, err := d.client.GetAuditLogs()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	opts, diags := listOptions(data.MaxResults, data.PageSize)
+	resp.Diagnostics.Append(diags...)
+
+	filter := thetalake.AuditLogFilter{
+		User:     data.User.ValueString(),
+		Action:   data.Action.ValueString(),
+		Resource: data.Resource.ValueString(),
+	}
+
+	now := time.Now()
+
+	if !data.Since.IsNull() {
+		since, err := parseTimeBound(data.Since.ValueString(), now)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("since"), "Invalid Time", err.Error())
+		}
+		filter.Since = since
+	}
+
+	if !data.Until.IsNull() {
+		until, err := parseTimeBound(data.Until.ValueString(), now)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("until"), "Invalid Time", err.Error())
+		}
+		filter.Until = until
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	logs, err := d.client.GetAuditLogs(ctx, filter, opts)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read audit logs, got error: %s", err))
 		return
@@ -110,3 +174,17 @@ func (d *AuditLogsDataSource) Read(ctx context.Context, req datasource.ReadReque
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// parseTimeBound accepts an RFC 3339 timestamp, or a duration that is
+// subtracted from now.
+func parseTimeBound(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("%q is neither an RFC 3339 timestamp nor a duration such as \"24h\"", value)
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestAccAuditLogsDataSource(t *testing.T) {
+	server := testAPI(t)
+	for i := range 5 {
+		server.AddAuditLogs(
+			thetalake.AuditLog{ID: fmt.Sprintf("login-%d", i), User: "jane@example.com", Action: "login", Timestamp: "2024-01-01T00:00:00Z"},
+			thetalake.AuditLog{ID: fmt.Sprintf("export-%d", i), User: "john@example.com", Action: "export", Timestamp: "2024-01-01T00:00:00Z"},
+		)
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuditLogsDataSourceConfig("login", 3),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.thetalake_audit_logs.test", "logs.#", "5"),
+					resource.TestCheckResourceAttr("data.thetalake_audit_logs.test", "logs.0.id", "login-0"),
+					resource.TestCheckResourceAttr("data.thetalake_audit_logs.test", "logs.4.id", "login-4"),
+				),
+			},
+		},
+	})
+}
+
+func testAccAuditLogsDataSourceConfig(action string, pageSize int) string {
+	return fmt.Sprintf(`
+data "thetalake_audit_logs" "test" {
+  action    = %[1]q
+  page_size = %[2]d
+}
+`, action, pageSize)
+}
@@ -3,21 +3,27 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 var _ datasource.DataSource = &CaseDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &CaseDataSource{}
 
 func NewCaseDataSource() datasource.DataSource {
 	return &CaseDataSource{}
 }
 
 type CaseDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 type CaseDataSourceModel struct {
@@ -27,6 +33,8 @@ type CaseDataSourceModel struct {
 	OpenDate    types.String `tfsdk:"open_date"`
 	Visibility  types.String `tfsdk:"visibility"`
 	Description types.String `tfsdk:"description"`
+	Status      types.String `tfsdk:"status"`
+	CloseDate   types.String `tfsdk:"close_date"`
 }
 
 func (d *CaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -35,20 +43,25 @@ func (d *CaseDataSource) Metadata(ctx context.Context, req datasource.MetadataRe
 
 func (d *CaseDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Theta Lake Case Data Source. Retrieves details of a specific case.",
+		MarkdownDescription: "Theta Lake Case Data Source. Retrieves details of a specific case by ID or by case number.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of the case to retrieve.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the case to retrieve. Exactly one of `id` and `number` must be set.",
 			},
 			"name": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The name of the case.",
 			},
 			"number": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The case number.",
+				MarkdownDescription: "The case number, such as CASE-2023-001. Exactly one of `id` and `number` must be set.",
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"open_date": schema.StringAttribute{
 				Computed:            true,
@@ -62,21 +75,38 @@ func (d *CaseDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "The description of the case.",
 			},
+			"status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The status of the case (OPEN or CLOSED).",
+			},
+			"close_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The date the case was closed, if it is closed.",
+			},
 		},
 	}
 }
 
+func (d *CaseDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("number"),
+		),
+	}
+}
+
 func (d *CaseDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -94,17 +124,30 @@ func (d *CaseDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	caseItem, err := d.client.GetCase(data.ID.ValueString())
+	var caseItem *thetalake.Case
+	var err error
+	if !data.Number.IsNull() {
+		caseItem, err = findCaseByNumber(ctx, d.client, data.Number.ValueString())
+	} else {
+		caseItem, err = d.client.GetCase(ctx, data.ID.ValueString())
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read case, got error: %s", err))
 		return
 	}
 
+	data.ID = types.StringValue(strconv.Itoa(caseItem.ID))
 	data.Name = types.StringValue(caseItem.Name)
-	data.Number = types.StringValue(caseItem.Number)
+	// A configured number may differ from the stored one in case only, and
+	// Terraform requires configured values to be kept.
+	if data.Number.IsNull() {
+		data.Number = types.StringValue(caseItem.Number)
+	}
 	data.OpenDate = types.StringValue(caseItem.OpenDate)
 	data.Visibility = types.StringValue(caseItem.Visibility)
 	data.Description = types.StringValue(caseItem.Description)
+	data.Status = types.StringValue(caseItem.CurrentStatus())
+	data.CloseDate = stringOrNull(caseItem.CloseDate)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -2,15 +2,18 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccCaseDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccCaseDataSourceConfig("test-case-ds", "CASE-DS-001", "PRIVATE"),
@@ -19,12 +22,38 @@ func TestAccCaseDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.thetalake_case.test", "number", "CASE-DS-001"),
 					resource.TestCheckResourceAttr("data.thetalake_case.test", "visibility", "PRIVATE"),
 					resource.TestCheckResourceAttrSet("data.thetalake_case.test", "id"),
+					resource.TestCheckResourceAttrPair("data.thetalake_case.by_number", "id", "thetalake_case.test", "id"),
+					resource.TestCheckResourceAttr("data.thetalake_case.by_number", "name", "test-case-ds"),
+					resource.TestCheckResourceAttrPair("data.thetalake_case.by_lower_number", "id", "thetalake_case.test", "id"),
+					resource.TestCheckResourceAttr("data.thetalake_case.by_lower_number", "number", "case-ds-001"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccCaseDataSourceLookupValidation(t *testing.T) {
+	testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "thetalake_case" "test" {}`,
+				ExpectError: regexp.MustCompile(`Exactly one of these attributes must be configured`),
+			},
+			{
+				Config:      `data "thetalake_case" "test" { number = "" }`,
+				ExpectError: regexp.MustCompile(`Attribute number string length must be at least 1`),
+			},
+			{
+				Config:      `data "thetalake_case" "test" { number = "CASE-MISSING" }`,
+				ExpectError: regexp.MustCompile(`no case has number "CASE-MISSING"`),
+			},
+		},
+	})
+}
+
 func testAccCaseDataSourceConfig(name, number, visibility string) string {
 	return fmt.Sprintf(`
 resource "thetalake_case" "test" {
@@ -36,5 +65,13 @@ resource "thetalake_case" "test" {
 data "thetalake_case" "test" {
   id = thetalake_case.test.id
 }
+
+data "thetalake_case" "by_number" {
+  number = thetalake_case.test.number
+}
+
+data "thetalake_case" "by_lower_number" {
+  number = lower(thetalake_case.test.number)
+}
 `, name, number, visibility)
 }
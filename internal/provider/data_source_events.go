@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,12 +19,14 @@ func NewEventsDataSource() datasource.DataSource {
 
 // EventsDataSource defines the data source implementation.
 type EventsDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // EventsDataSourceModel describes the data source data model.
 type EventsDataSourceModel struct {
-	Events []EventModel `tfsdk:"events"`
+	MaxResults types.Int64  `tfsdk:"max_results"`
+	PageSize   types.Int64  `tfsdk:"page_size"`
+	Events     []EventModel `tfsdk:"events"`
 }
 
 type EventModel struct {
@@ -43,6 +45,8 @@ func (d *EventsDataSource) Schema(ctx context.Context, req datasource.SchemaRequ
 		MarkdownDescription: "Theta Lake Events Data Source",
 
 		Attributes: map[string]schema.Attribute{
+			"max_results": maxResultsAttribute(),
+			"page_size":   pageSizeAttribute(),
 			"events": schema.ListNestedAttribute{
 				Computed: true,
 				NestedObject: schema.NestedAttributeObject{
@@ -71,12 +75,12 @@ func (d *EventsDataSource) Configure(ctx context.Context, req datasource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -88,7 +92,16 @@ func (d *EventsDataSource) Configure(ctx context.Context, req datasource.Configu
 func (d *EventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data EventsDataSourceModel
 
-	events, err := d.client.GetEvents()
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	opts, diags := listOptions(data.MaxResults, data.PageSize)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	events, err := d.client.GetEvents(ctx, opts)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read events, got error: %s", err))
 		return
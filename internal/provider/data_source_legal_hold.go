@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 var _ datasource.DataSource = &LegalHoldDataSource{}
@@ -17,7 +17,7 @@ func NewLegalHoldDataSource() datasource.DataSource {
 }
 
 type LegalHoldDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 type LegalHoldDataSourceModel struct {
@@ -61,12 +61,12 @@ func (d *LegalHoldDataSource) Configure(ctx context.Context, req datasource.Conf
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -84,7 +84,7 @@ func (d *LegalHoldDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	hold, err := d.client.GetLegalHold(data.ID.ValueString())
+	hold, err := d.client.GetLegalHold(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read legal hold, got error: %s", err))
 		return
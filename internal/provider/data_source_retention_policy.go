@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 var _ datasource.DataSource = &RetentionPolicyDataSource{}
@@ -17,7 +17,7 @@ func NewRetentionPolicyDataSource() datasource.DataSource {
 }
 
 type RetentionPolicyDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 type RetentionPolicyDataSourceModel struct {
@@ -61,12 +61,12 @@ func (d *RetentionPolicyDataSource) Configure(ctx context.Context, req datasourc
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -84,7 +84,7 @@ func (d *RetentionPolicyDataSource) Read(ctx context.Context, req datasource.Rea
 		return
 	}
 
-	policy, err := d.client.GetRetentionPolicy(data.ID.ValueString())
+	policy, err := d.client.GetRetentionPolicy(ctx, data.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read retention policy, got error: %s", err))
 		return
@@ -0,0 +1,138 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &RolesDataSource{}
+
+func NewRolesDataSource() datasource.DataSource {
+	return &RolesDataSource{}
+}
+
+// RolesDataSource defines the data source implementation.
+type RolesDataSource struct {
+	client thetalake.API
+}
+
+// RolesDataSourceModel describes the data source data model.
+type RolesDataSourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	MaxResults types.Int64  `tfsdk:"max_results"`
+	PageSize   types.Int64  `tfsdk:"page_size"`
+	Roles      []RoleModel  `tfsdk:"roles"`
+}
+
+type RoleModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permissions"`
+	BuiltIn     types.Bool   `tfsdk:"built_in"`
+}
+
+func (d *RolesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_roles"
+}
+
+func (d *RolesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Theta Lake Roles Data Source. Lists the built-in and custom roles that can be assigned to users.",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return the role with this name, compared case-insensitively.",
+			},
+			"max_results": maxResultsAttribute(),
+			"page_size":   pageSizeAttribute(),
+			"roles": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"description": schema.StringAttribute{
+							Computed: true,
+						},
+						"permissions": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"built_in": schema.BoolAttribute{
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RolesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(thetalake.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *RolesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RolesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	opts, diags := listOptions(data.MaxResults, data.PageSize)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roles, err := d.client.GetRoles(ctx, thetalake.RoleFilter{Name: data.Name.ValueString()}, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read roles, got error: %s", err))
+		return
+	}
+
+	// An empty list rather than null, so checks such as length() work when
+	// nothing matches.
+	data.Roles = []RoleModel{}
+	for _, role := range roles {
+		permissions, diags := types.SetValueFrom(ctx, types.StringType, role.Permissions)
+		resp.Diagnostics.Append(diags...)
+
+		data.Roles = append(data.Roles, RoleModel{
+			ID:          types.StringValue(strconv.Itoa(role.ID)),
+			Name:        types.StringValue(role.Name),
+			Description: types.StringValue(role.Description),
+			Permissions: permissions,
+			BuiltIn:     types.BoolValue(role.BuiltIn),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
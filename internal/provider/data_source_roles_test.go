@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestAccRolesDataSource(t *testing.T) {
+	server := testAPI(t)
+	server.AddRole(thetalake.Role{ID: 1, Name: "Administrator", Permissions: []string{"*"}})
+	server.AddRole(thetalake.Role{ID: 2, Name: "Reviewer", Description: "Reviews records", Permissions: []string{"records:read", "records:review"}})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "thetalake_role" "auditor" {
+  name        = "Auditor"
+  permissions = ["audit_logs:read"]
+}
+
+data "thetalake_roles" "all" {
+  depends_on = [thetalake_role.auditor]
+}
+
+data "thetalake_roles" "reviewer" {
+  name = "reviewer"
+}
+
+data "thetalake_roles" "missing" {
+  name = "missing"
+}
+
+output "missing_count" {
+  value = length(data.thetalake_roles.missing.roles)
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.thetalake_roles.all", "roles.#", "3"),
+					resource.TestCheckResourceAttr("data.thetalake_roles.all", "roles.0.built_in", "true"),
+					resource.TestCheckResourceAttr("data.thetalake_roles.all", "roles.2.name", "Auditor"),
+					resource.TestCheckResourceAttr("data.thetalake_roles.all", "roles.2.built_in", "false"),
+					resource.TestCheckResourceAttr("data.thetalake_roles.reviewer", "roles.#", "1"),
+					resource.TestCheckResourceAttr("data.thetalake_roles.reviewer", "roles.0.id", "2"),
+					resource.TestCheckTypeSetElemAttr("data.thetalake_roles.reviewer", "roles.0.permissions.*", "records:review"),
+					resource.TestCheckOutput("missing_count", "0"),
+				),
+			},
+		},
+	})
+}
@@ -7,7 +7,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -19,7 +19,7 @@ func NewSystemStatusDataSource() datasource.DataSource {
 
 // SystemStatusDataSource defines the data source implementation.
 type SystemStatusDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // SystemStatusDataSourceModel describes the data source data model.
@@ -59,12 +59,12 @@ func (d *SystemStatusDataSource) Configure(ctx context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -76,7 +76,7 @@ func (d *SystemStatusDataSource) Configure(ctx context.Context, req datasource.C
 func (d *SystemStatusDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data SystemStatusDataSourceModel
 
-	status, err := d.client.GetSystemStatus()
+	status, err := d.client.GetSystemStatus(ctx)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read system status, got error: %s", err))
 		return
@@ -3,21 +3,26 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 var _ datasource.DataSource = &UserDataSource{}
+var _ datasource.DataSourceWithConfigValidators = &UserDataSource{}
 
 func NewUserDataSource() datasource.DataSource {
 	return &UserDataSource{}
 }
 
 type UserDataSource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 type UserDataSourceModel struct {
@@ -26,6 +31,7 @@ type UserDataSourceModel struct {
 	Email    types.String `tfsdk:"email"`
 	RoleID   types.Int64  `tfsdk:"role_id"`
 	SearchID types.Int64  `tfsdk:"search_id"`
+	Active   types.Bool   `tfsdk:"active"`
 }
 
 func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -34,20 +40,25 @@ func (d *UserDataSource) Metadata(ctx context.Context, req datasource.MetadataRe
 
 func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Theta Lake User Data Source. Retrieves details of a specific user.",
+		MarkdownDescription: "Theta Lake User Data Source. Retrieves details of a specific user by ID or by email.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "The ID of the user to retrieve.",
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "The ID of the user to retrieve. Exactly one of `id` and `email` must be set.",
 			},
 			"name": schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: "The name of the user.",
 			},
 			"email": schema.StringAttribute{
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The email of the user.",
+				MarkdownDescription: "The email of the user, compared case-insensitively. Exactly one of `id` and `email` must be set.",
+				Validators: []validator.String{
+					isEmail(),
+				},
 			},
 			"role_id": schema.Int64Attribute{
 				Computed:            true,
@@ -57,21 +68,34 @@ func (d *UserDataSource) Schema(ctx context.Context, req datasource.SchemaReques
 				Computed:            true,
 				MarkdownDescription: "The search ID of the user.",
 			},
+			"active": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the user can sign in.",
+			},
 		},
 	}
 }
 
+func (d *UserDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("id"),
+			path.MatchRoot("email"),
+		),
+	}
+}
+
 func (d *UserDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -89,16 +113,28 @@ func (d *UserDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 		return
 	}
 
-	user, err := d.client.GetUser(data.ID.ValueString())
+	var user *thetalake.User
+	var err error
+	if !data.Email.IsNull() {
+		user, err = findUserByEmail(ctx, d.client, data.Email.ValueString())
+	} else {
+		user, err = d.client.GetUser(ctx, data.ID.ValueString())
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
 		return
 	}
 
+	data.ID = types.StringValue(strconv.Itoa(user.ID))
 	data.Name = types.StringValue(user.Name)
-	data.Email = types.StringValue(user.Email)
+	// A configured email may differ from the stored one in case only, and
+	// Terraform requires configured values to be kept.
+	if data.Email.IsNull() {
+		data.Email = types.StringValue(user.Email)
+	}
 	data.RoleID = types.Int64Value(int64(user.RoleID))
 	data.SearchID = types.Int64Value(int64(user.SearchID))
+	data.Active = types.BoolValue(user.IsActive())
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
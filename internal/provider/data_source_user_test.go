@@ -2,15 +2,18 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 )
 
 func TestAccUserDataSource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccUserDataSourceConfig("test-user-ds", "test-ds@example.com"),
@@ -18,17 +21,45 @@ func TestAccUserDataSource(t *testing.T) {
 					resource.TestCheckResourceAttr("data.thetalake_user.test", "name", "test-user-ds"),
 					resource.TestCheckResourceAttr("data.thetalake_user.test", "email", "test-ds@example.com"),
 					resource.TestCheckResourceAttrSet("data.thetalake_user.test", "id"),
+					resource.TestCheckResourceAttr("data.thetalake_user.test", "active", "true"),
+					resource.TestCheckResourceAttrPair("data.thetalake_user.by_email", "id", "thetalake_user.test", "id"),
+					resource.TestCheckResourceAttr("data.thetalake_user.by_email", "name", "test-user-ds"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccUserDataSourceLookupValidation(t *testing.T) {
+	testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "thetalake_user" "test" {}`,
+				ExpectError: regexp.MustCompile(`Exactly one of these attributes must be configured`),
+			},
+			{
+				Config:      `data "thetalake_user" "test" { email = "nobody@example.com" }`,
+				ExpectError: regexp.MustCompile(`no user has email "nobody@example.com"`),
+			},
+		},
+	})
+}
+
 func testAccUserDataSourceConfig(name, email string) string {
 	return fmt.Sprintf(`
 resource "thetalake_user" "test" {
-  name  = %[1]q
-  email = %[2]q
+  name                  = %[1]q
+  email                 = %[2]q
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 1
+}
+
+data "thetalake_user" "by_email" {
+  email = upper(thetalake_user.test.email)
 }
 
 data "thetalake_user" "test" {
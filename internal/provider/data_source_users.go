@@ -0,0 +1,198 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &UsersDataSource{}
+
+func NewUsersDataSource() datasource.DataSource {
+	return &UsersDataSource{}
+}
+
+// UsersDataSource defines the data source implementation.
+type UsersDataSource struct {
+	client thetalake.API
+}
+
+// UsersDataSourceModel describes the data source data model.
+type UsersDataSourceModel struct {
+	RoleID           types.Int64  `tfsdk:"role_id"`
+	EmailDomain      types.String `tfsdk:"email_domain"`
+	EmailRegex       types.String `tfsdk:"email_regex"`
+	DirectoryGroupID types.String `tfsdk:"directory_group_id"`
+	Active           types.Bool   `tfsdk:"active"`
+	MaxResults       types.Int64  `tfsdk:"max_results"`
+	PageSize         types.Int64  `tfsdk:"page_size"`
+	Users            []UserModel  `tfsdk:"users"`
+}
+
+type UserModel struct {
+	ID                types.String `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Email             types.String `tfsdk:"email"`
+	RoleID            types.Int64  `tfsdk:"role_id"`
+	SearchID          types.Int64  `tfsdk:"search_id"`
+	Active            types.Bool   `tfsdk:"active"`
+	DirectoryGroupIDs types.Set    `tfsdk:"directory_group_ids"`
+}
+
+func (d *UsersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+func (d *UsersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Theta Lake Users Data Source. Lists the users matching every filter that is set.",
+
+		Attributes: map[string]schema.Attribute{
+			"role_id": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Only return users with this role.",
+			},
+			"email_domain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return users whose email address is in this domain, such as `example.com`.",
+			},
+			"email_regex": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return users whose email address matches this regular expression (RE2 syntax). Prefix it with `(?i)` to ignore case.",
+				Validators: []validator.String{
+					isRegexp(),
+				},
+			},
+			"directory_group_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return members of this directory group.",
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Only return active (`true`) or disabled (`false`) users.",
+			},
+			"max_results": maxResultsAttribute(),
+			"page_size":   pageSizeAttribute(),
+			"users": schema.ListNestedAttribute{
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							Computed: true,
+						},
+						"name": schema.StringAttribute{
+							Computed: true,
+						},
+						"email": schema.StringAttribute{
+							Computed: true,
+						},
+						"role_id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"search_id": schema.Int64Attribute{
+							Computed: true,
+						},
+						"active": schema.BoolAttribute{
+							Computed: true,
+						},
+						"directory_group_ids": schema.SetAttribute{
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *UsersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(thetalake.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+func (d *UsersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data UsersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	opts, diags := listOptions(data.MaxResults, data.PageSize)
+	resp.Diagnostics.Append(diags...)
+
+	filter := thetalake.UserFilter{
+		EmailDomain:      data.EmailDomain.ValueString(),
+		RoleID:           int(data.RoleID.ValueInt64()),
+		DirectoryGroupID: parseID(path.Root("directory_group_id"), data.DirectoryGroupID, &resp.Diagnostics),
+	}
+
+	if !data.EmailRegex.IsNull() {
+		pattern, err := regexp.Compile(data.EmailRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("email_regex"), "Invalid Regular Expression", err.Error())
+		}
+		filter.EmailPattern = pattern
+	}
+
+	if !data.Active.IsNull() {
+		active := data.Active.ValueBool()
+		filter.Active = &active
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	users, err := d.client.SearchUsers(ctx, filter, opts)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read users, got error: %s", err))
+		return
+	}
+
+	// An empty list rather than null, so checks such as length() work when
+	// nothing matches.
+	data.Users = []UserModel{}
+	for _, user := range users {
+		groupIDs := make([]string, 0, len(user.DirectoryGroupIDs))
+		for _, id := range user.DirectoryGroupIDs {
+			groupIDs = append(groupIDs, strconv.Itoa(id))
+		}
+
+		directoryGroupIDs, diags := types.SetValueFrom(ctx, types.StringType, groupIDs)
+		resp.Diagnostics.Append(diags...)
+
+		data.Users = append(data.Users, UserModel{
+			ID:                types.StringValue(strconv.Itoa(user.ID)),
+			Name:              types.StringValue(user.Name),
+			Email:             types.StringValue(user.Email),
+			RoleID:            types.Int64Value(int64(user.RoleID)),
+			SearchID:          types.Int64Value(int64(user.SearchID)),
+			Active:            types.BoolValue(user.IsActive()),
+			DirectoryGroupIDs: directoryGroupIDs,
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
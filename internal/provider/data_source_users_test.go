@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccUsersDataSource(t *testing.T) {
+	server := testAPI(t)
+	var aliceID string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUsersDataSourceConfig(""),
+				Check: resource.TestCheckResourceAttrWith("thetalake_user.alice", "id", func(id string) error {
+					aliceID = id
+					return nil
+				}),
+			},
+			{
+				// The directory sync adds Alice to a group.
+				PreConfig: func() {
+					id, _ := strconv.Atoi(aliceID)
+					server.AddUserToDirectoryGroup(id, 42)
+				},
+				Config: testAccUsersDataSourceConfig(`
+data "thetalake_users" "all" {
+  depends_on = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+
+data "thetalake_users" "reviewers" {
+  role_id    = 2
+  depends_on = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+
+data "thetalake_users" "example_com" {
+  email_domain = "@example.com"
+  depends_on   = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+
+data "thetalake_users" "regex" {
+  email_regex = "(?i)^(ALICE|carol)@"
+  depends_on  = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+
+data "thetalake_users" "group" {
+  directory_group_id = "42"
+  depends_on         = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+
+data "thetalake_users" "disabled" {
+  active     = false
+  depends_on = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+
+data "thetalake_users" "none" {
+  role_id      = 2
+  email_domain = "other.test"
+  depends_on   = [thetalake_user.alice, thetalake_user.bob, thetalake_user.carol]
+}
+`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.thetalake_users.all", "users.#", "3"),
+					resource.TestCheckResourceAttr("data.thetalake_users.reviewers", "users.#", "1"),
+					resource.TestCheckResourceAttrPair("data.thetalake_users.reviewers", "users.0.id", "thetalake_user.bob", "id"),
+					resource.TestCheckResourceAttr("data.thetalake_users.example_com", "users.#", "2"),
+					resource.TestCheckResourceAttr("data.thetalake_users.regex", "users.#", "2"),
+					// The users are created in parallel, so their order is not fixed.
+					resource.TestCheckTypeSetElemNestedAttrs("data.thetalake_users.regex", "users.*", map[string]string{"email": "alice@example.com"}),
+					resource.TestCheckTypeSetElemNestedAttrs("data.thetalake_users.regex", "users.*", map[string]string{"email": "carol@other.test"}),
+					resource.TestCheckResourceAttr("data.thetalake_users.group", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.thetalake_users.group", "users.0.name", "alice"),
+					resource.TestCheckTypeSetElemAttr("data.thetalake_users.group", "users.0.directory_group_ids.*", "42"),
+					resource.TestCheckResourceAttr("data.thetalake_users.disabled", "users.#", "1"),
+					resource.TestCheckResourceAttr("data.thetalake_users.disabled", "users.0.name", "carol"),
+					resource.TestCheckResourceAttr("data.thetalake_users.disabled", "users.0.active", "false"),
+					resource.TestCheckResourceAttr("data.thetalake_users.none", "users.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func testAccUsersDataSourceConfig(dataSources string) string {
+	return fmt.Sprintf(`
+resource "thetalake_user" "alice" {
+  name                  = "alice"
+  email                 = "alice@example.com"
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 1
+}
+
+resource "thetalake_user" "bob" {
+  name                  = "bob"
+  email                 = "bob@example.com"
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 2
+}
+
+resource "thetalake_user" "carol" {
+  name                  = "carol"
+  email                 = "carol@other.test"
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 1
+  active                = false
+}
+%s`, dataSources)
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// maxResultsAttribute and pageSizeAttribute are shared by the list data sources.
+func maxResultsAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Maximum number of entries to return. Pages are fetched until this many entries have been collected. Leave unset to read every page.",
+	}
+}
+
+func pageSizeAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		Optional:            true,
+		MarkdownDescription: "Number of entries requested from the API per page. Leave unset to use the API default.",
+	}
+}
+
+// listOptions converts the paging attributes into thetalake.ListOptions.
+func listOptions(maxResults, pageSize types.Int64) (thetalake.ListOptions, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var opts thetalake.ListOptions
+
+	if !maxResults.IsNull() {
+		if maxResults.ValueInt64() < 1 {
+			diags.AddAttributeError(path.Root("max_results"), "Invalid Paging Configuration", "'max_results' must be at least 1.")
+		}
+		opts.MaxResults = int(maxResults.ValueInt64())
+	}
+
+	if !pageSize.IsNull() {
+		if pageSize.ValueInt64() < 1 {
+			diags.AddAttributeError(path.Root("page_size"), "Invalid Paging Configuration", "'page_size' must be at least 1.")
+		}
+		opts.PageSize = int(pageSize.ValueInt64())
+	}
+
+	return opts, diags
+}
@@ -2,13 +2,20 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure ThetaLakeProvider satisfies various provider interfaces.
@@ -20,12 +27,42 @@ type ThetaLakeProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// wrapTransport, when set, wraps the transport of every client the
+	// provider configures. Tests use it to record and replay API traffic.
+	wrapTransport func(http.RoundTripper) http.RoundTripper
 }
 
 // ThetaLakeProviderModel describes the provider data model.
 type ThetaLakeProviderModel struct {
-	Endpoint types.String `tfsdk:"endpoint"`
-	Token    types.String `tfsdk:"token"`
+	Endpoint        types.String `tfsdk:"endpoint"`
+	Token           types.String `tfsdk:"token"`
+	Profile         types.String `tfsdk:"profile"`
+	CredentialsFile types.String `tfsdk:"credentials_file"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait    types.Int64  `tfsdk:"retry_max_wait"`
+
+	RequestsPerSecond     types.Float64 `tfsdk:"requests_per_second"`
+	Burst                 types.Int64   `tfsdk:"burst"`
+	MaxConcurrentRequests types.Int64   `tfsdk:"max_concurrent_requests"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	RequestTimeout     types.Int64  `tfsdk:"request_timeout"`
+
+	OAuth2 *OAuth2Model `tfsdk:"oauth2"`
+}
+
+// OAuth2Model describes the oauth2 block of the provider configuration.
+type OAuth2Model struct {
+	ClientID     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+	TokenURL     types.String `tfsdk:"token_url"`
+	Scopes       types.List   `tfsdk:"scopes"`
 }
 
 func (p *ThetaLakeProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -37,14 +74,96 @@ func (p *ThetaLakeProvider) Schema(ctx context.Context, req provider.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"endpoint": schema.StringAttribute{
-				MarkdownDescription: "The Theta Lake API Endpoint.",
+				MarkdownDescription: "The Theta Lake API Endpoint. Can also be set with the `THETALAKE_ENDPOINT` environment variable or an `endpoint` entry in the credentials profile.",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The Theta Lake API Token.",
+				MarkdownDescription: "The Theta Lake API Token. Can also be set with the `THETALAKE_TOKEN` environment variable or a `token` entry in the credentials profile.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"profile": schema.StringAttribute{
+				MarkdownDescription: "Name of the profile to read from the credentials file. Can also be set with the `THETALAKE_PROFILE` environment variable. Defaults to `default`, which is only used if present.",
+				Optional:            true,
+			},
+			"credentials_file": schema.StringAttribute{
+				MarkdownDescription: "Path to the credentials file. Can also be set with the `THETALAKE_CREDENTIALS_FILE` environment variable. Defaults to `~/.thetalake/credentials`.",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of times an idempotent request (GET, PUT, DELETE) is retried after a rate limit (429), a transient server error (502, 503, 504) or a network failure. Set to `0` to disable retries. Defaults to `%d`.", thetalake.DefaultMaxRetries),
+				Optional:            true,
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of seconds to wait between two attempts, including waits requested by a `Retry-After` header. Defaults to `%d`.", int(thetalake.DefaultRetryWaitMax.Seconds())),
+				Optional:            true,
+			},
+			"requests_per_second": schema.Float64Attribute{
+				MarkdownDescription: "Client-side limit on the number of API requests per second, shared by all resources and data sources. Unset or `0` means no limit.",
+				Optional:            true,
+			},
+			"burst": schema.Int64Attribute{
+				MarkdownDescription: "Number of requests that may be sent at once before `requests_per_second` pacing applies. Requires `requests_per_second`; defaults to it rounded up.",
+				Optional:            true,
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at the same time. Unset or `0` means no cap.",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM bundle of CA certificates to trust in addition to the system pool, for example the CA of a TLS-inspecting proxy.",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM-encoded CA certificates to trust in addition to the system pool. Can be combined with `ca_cert_file`.",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "Client certificate for mutual TLS, either PEM-encoded or a path to a PEM file. Requires `client_key`.",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "Private key of `client_cert`, either PEM-encoded or a path to a PEM file.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"proxy_url": schema.StringAttribute{
+				MarkdownDescription: "URL of the HTTP proxy used to reach the API. Defaults to the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables.",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Disable verification of the API's TLS certificate. **Never use this in production**; configure `ca_cert_file` or `ca_cert_pem` instead.",
+				Optional:            true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Timeout in seconds for a single HTTP request, including reading the response. Defaults to `%d`.", int(thetalake.DefaultRequestTimeout.Seconds())),
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"oauth2": schema.SingleNestedBlock{
+				MarkdownDescription: "Authenticate with the OAuth2 client credentials grant instead of a static `token`. Access tokens are cached and renewed when they expire or are rejected.",
+				Attributes: map[string]schema.Attribute{
+					"client_id": schema.StringAttribute{
+						MarkdownDescription: "OAuth2 client ID. Can also be set with the `THETALAKE_CLIENT_ID` environment variable or a `client_id` entry in the credentials profile.",
+						Optional:            true,
+					},
+					"client_secret": schema.StringAttribute{
+						MarkdownDescription: "OAuth2 client secret. Can also be set with the `THETALAKE_CLIENT_SECRET` environment variable or a `client_secret` entry in the credentials profile.",
+						Optional:            true,
+						Sensitive:           true,
+					},
+					"token_url": schema.StringAttribute{
+						MarkdownDescription: "URL of the OAuth2 token endpoint. Can also be set with the `THETALAKE_TOKEN_URL` environment variable or a `token_url` entry in the credentials profile.",
+						Optional:            true,
+					},
+					"scopes": schema.ListAttribute{
+						MarkdownDescription: "Scopes to request with each access token.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -58,20 +177,162 @@ func (p *ThetaLakeProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	endpoint := data.Endpoint.ValueString()
-	token := data.Token.ValueString()
+	// A profile named in the configuration or the environment must exist; the
+	// default profile is only used when the credentials file provides it.
+	profileName := resolveSetting(data.Profile, envProfile, nil, "")
+	profileRequired := profileName != ""
+	if profileName == "" {
+		profileName = defaultProfile
+	}
 
-	if token == "" {
-		resp.Diagnostics.AddError("Missing API Token", "The 'token' provider configuration is required.")
+	credentialsFile := resolveSetting(data.CredentialsFile, envCredentialsFile, nil, "")
+	if credentialsFile == "" {
+		credentialsFile = defaultCredentialsFile()
+	}
+
+	profile, err := loadProfile(credentialsFile, profileName, profileRequired)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("profile"), "Unable to Load Credentials Profile", err.Error())
 		return
 	}
 
+	endpoint := resolveSetting(data.Endpoint, envEndpoint, profile, "endpoint")
+	token := resolveSetting(data.Token, envToken, profile, "token")
+
+	oauth2 := data.OAuth2
+	if oauth2 == nil {
+		oauth2 = &OAuth2Model{}
+	}
+
+	credentials := thetalake.ClientCredentialsConfig{
+		ClientID:     resolveSetting(oauth2.ClientID, envClientID, profile, "client_id"),
+		ClientSecret: resolveSetting(oauth2.ClientSecret, envClientSecret, profile, "client_secret"),
+		TokenURL:     resolveSetting(oauth2.TokenURL, envTokenURL, profile, "token_url"),
+	}
+
+	if !oauth2.Scopes.IsNull() {
+		resp.Diagnostics.Append(oauth2.Scopes.ElementsAs(ctx, &credentials.Scopes, false)...)
+	}
+
+	useOAuth2, err := chooseOAuth2(
+		authSource{
+			name:   "The provider configuration",
+			token:  data.Token.ValueString() != "",
+			oauth2: data.OAuth2 != nil,
+		},
+		authSource{
+			name:   "The environment",
+			token:  os.Getenv(envToken) != "",
+			oauth2: os.Getenv(envClientID) != "" || os.Getenv(envClientSecret) != "" || os.Getenv(envTokenURL) != "",
+		},
+		authSource{
+			name:   fmt.Sprintf("The %q profile of %s", profileName, credentialsFile),
+			token:  profile["token"] != "",
+			oauth2: profile["client_id"] != "" || profile["client_secret"] != "" || profile["token_url"] != "",
+		},
+	)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("token"), "Conflicting Authentication Settings", err.Error())
+	}
+
+	if useOAuth2 {
+		if credentials.ClientID == "" || credentials.ClientSecret == "" || credentials.TokenURL == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("oauth2"),
+				"Incomplete OAuth2 Configuration",
+				fmt.Sprintf("OAuth2 authentication needs 'client_id', 'client_secret' and 'token_url'. Each can also come from %s, %s and %s, or the credentials profile.", envClientID, envClientSecret, envTokenURL),
+			)
+		}
+	} else if token == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Missing API Token",
+			fmt.Sprintf("Set the 'token' provider attribute, the %s environment variable, or a 'token' entry in the %q profile of %s.", envToken, profileName, credentialsFile),
+		)
+	}
+
 	if endpoint == "" {
-		resp.Diagnostics.AddError("Missing API Endpoint", "The 'endpoint' provider configuration is required.")
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoint"),
+			"Missing API Endpoint",
+			fmt.Sprintf("Set the 'endpoint' provider attribute, the %s environment variable, or an 'endpoint' entry in the %q profile of %s.", envEndpoint, profileName, credentialsFile),
+		)
+	}
+
+	if !data.MaxRetries.IsNull() && data.MaxRetries.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_retries"), "Invalid Retry Configuration", "'max_retries' must not be negative.")
+	}
+
+	if !data.RetryMaxWait.IsNull() && data.RetryMaxWait.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("retry_max_wait"), "Invalid Retry Configuration", "'retry_max_wait' must be at least 1 second.")
+	}
+
+	if !data.RequestsPerSecond.IsNull() && data.RequestsPerSecond.ValueFloat64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("requests_per_second"), "Invalid Rate Limit Configuration", "'requests_per_second' must not be negative.")
+	}
+
+	if !data.Burst.IsNull() && data.Burst.ValueInt64() < 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("burst"), "Invalid Rate Limit Configuration", "'burst' must be at least 1.")
+	}
+
+	// Without a rate there is nothing for the burst to apply to.
+	if !data.Burst.IsNull() && data.RequestsPerSecond.ValueFloat64() <= 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("burst"), "Invalid Rate Limit Configuration", "'burst' requires a positive 'requests_per_second'.")
+	}
+
+	if !data.MaxConcurrentRequests.IsNull() && data.MaxConcurrentRequests.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("max_concurrent_requests"), "Invalid Rate Limit Configuration", "'max_concurrent_requests' must not be negative.")
+	}
+
+	transportConfig, diags := transportConfig(data)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	c, err := client.NewClient(endpoint, token)
+	if transportConfig.InsecureSkipVerify {
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("insecure_skip_verify"),
+			"TLS Certificate Verification Disabled",
+			"The provider will not verify the identity of the Theta Lake API. Anyone able to intercept the connection can read and modify requests, including the API credentials. Trust a private CA with 'ca_cert_file' or 'ca_cert_pem' instead.",
+		)
+	}
+
+	httpClient, err := thetalake.NewHTTPClient(transportConfig)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to configure HTTP transport", err.Error())
+		return
+	}
+
+	if p.wrapTransport != nil {
+		httpClient.Transport = p.wrapTransport(httpClient.Transport)
+	}
+
+	maxRetries := thetalake.DefaultMaxRetries
+	if !data.MaxRetries.IsNull() {
+		maxRetries = int(data.MaxRetries.ValueInt64())
+	}
+
+	retryWaitMax := thetalake.DefaultRetryWaitMax
+	if !data.RetryMaxWait.IsNull() {
+		retryWaitMax = time.Duration(data.RetryMaxWait.ValueInt64()) * time.Second
+	}
+
+	opts := []thetalake.Option{
+		thetalake.WithHTTPClient(httpClient),
+		thetalake.WithUserAgent(fmt.Sprintf("terraform-provider-thetalake/%s %s", p.version, thetalake.DefaultUserAgent)),
+		thetalake.WithRetry(maxRetries, thetalake.DefaultRetryWaitMin, retryWaitMax),
+		thetalake.WithRateLimit(data.RequestsPerSecond.ValueFloat64(), int(data.Burst.ValueInt64())),
+		thetalake.WithMaxConcurrency(int(data.MaxConcurrentRequests.ValueInt64())),
+	}
+
+	if useOAuth2 {
+		token = ""
+		opts = append(opts, thetalake.WithTokenSource(thetalake.NewClientCredentials(credentials, httpClient)))
+	}
+
+	c, err := thetalake.NewClient(endpoint, token, opts...)
 	if err != nil {
 		resp.Diagnostics.AddError("Unable to create client", err.Error())
 		return
@@ -93,6 +354,9 @@ func (p *ThetaLakeProvider) Resources(ctx context.Context) []func() resource.Res
 		NewExportResource,
 		NewRecordResource,
 		NewCaseRecordResource,
+		NewCaseRecordsResource,
+		NewCaseMemberResource,
+		NewRoleResource,
 	}
 }
 
@@ -106,6 +370,7 @@ func (p *ThetaLakeProvider) DataSources(ctx context.Context) []func() datasource
 		NewAnalysisDataSource,
 		NewCaseDataSource,
 		NewUserDataSource,
+		NewUsersDataSource,
 		NewDirectoryGroupDataSource,
 		NewTagDataSource,
 		NewRetentionPolicyDataSource,
@@ -113,7 +378,70 @@ func (p *ThetaLakeProvider) DataSources(ctx context.Context) []func() datasource
 		NewExportDataSource,
 		NewRecordDataSource,
 		NewIntegrationStateDataSource,
+		NewRolesDataSource,
+	}
+}
+
+// transportConfig collects the TLS, proxy and timeout settings.
+func transportConfig(data ThetaLakeProviderModel) (thetalake.TransportConfig, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	cfg := thetalake.TransportConfig{
+		ProxyURL:           data.ProxyURL.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	if !data.CACertFile.IsNull() {
+		pem, err := os.ReadFile(data.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("ca_cert_file"), "Unable to Read CA Certificates", err.Error())
+		}
+		cfg.CACertPEM = append(cfg.CACertPEM, pem...)
+	}
+
+	if !data.CACertPEM.IsNull() {
+		cfg.CACertPEM = append(cfg.CACertPEM, '\n')
+		cfg.CACertPEM = append(cfg.CACertPEM, data.CACertPEM.ValueString()...)
 	}
+
+	if !data.ClientCert.IsNull() {
+		pem, err := pemOrFile(data.ClientCert.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_cert"), "Unable to Read Client Certificate", err.Error())
+		}
+		cfg.ClientCertPEM = pem
+	}
+
+	if !data.ClientKey.IsNull() {
+		pem, err := pemOrFile(data.ClientKey.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("client_key"), "Unable to Read Client Key", err.Error())
+		}
+		cfg.ClientKeyPEM = pem
+	}
+
+	if data.ClientCert.IsNull() != data.ClientKey.IsNull() {
+		diags.AddError("Incomplete Mutual TLS Configuration", "'client_cert' and 'client_key' must be set together.")
+	}
+
+	if !data.RequestTimeout.IsNull() {
+		if data.RequestTimeout.ValueInt64() < 1 {
+			diags.AddAttributeError(path.Root("request_timeout"), "Invalid Request Timeout", "'request_timeout' must be at least 1 second.")
+		}
+		cfg.Timeout = time.Duration(data.RequestTimeout.ValueInt64()) * time.Second
+	}
+
+	return cfg, diags
+}
+
+// pemOrFile returns value itself when it is PEM-encoded and otherwise reads
+// the file it names.
+func pemOrFile(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN ") {
+		return []byte(value), nil
+	}
+
+	return os.ReadFile(value)
 }
 
 func New() provider.Provider {
@@ -1,29 +1,317 @@
 package provider
 
 import (
+	"fmt"
+	"net/http"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/fakeapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
+// Environment variables that switch the provider tests from the fake API to
+// a live tenant (record) or to recorded traffic (replay).
+const (
+	envCassette = "THETALAKE_CASSETTE"
+
+	// cassetteEndpoint is the endpoint used in replay mode. No request leaves
+	// the process, but the path must match the one recorded.
+	cassetteEndpoint = "https://cassette.invalid/api/v1"
+)
+
+// testCassette is the cassette of the running test, if any. Provider tests
+// never run in parallel, because they set environment variables.
+var testCassette *thetalake.Cassette
+
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
 // acceptance testing. The factory function will be invoked for every Terraform
 // CLI command executed to create a provider server to which the CLI can
 // reattach.
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
-	"thetalake": providerserver.NewProtocol6WithError(New()),
+	"thetalake": providerserver.NewProtocol6WithError(&ThetaLakeProvider{
+		version: "test",
+		wrapTransport: func(next http.RoundTripper) http.RoundTripper {
+			if testCassette == nil {
+				return next
+			}
+			return testCassette.Transport(next)
+		},
+	}),
 }
 
 func testAccPreCheck(t *testing.T) {
-	// You can add code here to run prior to any test case execution, for example assertions
-	// about the appropriate environment variables being set are common to see in a pre-check
-	// function.
-	if v := os.Getenv("THETALAKE_TOKEN"); v == "" {
+	if v := os.Getenv(envToken); v == "" {
 		t.Fatal("THETALAKE_TOKEN must be set for acceptance tests")
 	}
-	if v := os.Getenv("THETALAKE_ENDPOINT"); v == "" {
+	if v := os.Getenv(envEndpoint); v == "" {
 		t.Fatal("THETALAKE_ENDPOINT must be set for acceptance tests")
 	}
 }
+
+// testAPI points the provider at the API the test runs against, so the test
+// can run with resource.UnitTest. By default that is an in-process fake; with
+// THETALAKE_CASSETTE set it is either:
+//
+//   - record: the live tenant named by THETALAKE_ENDPOINT and THETALAKE_TOKEN,
+//     with the traffic saved to testdata/cassettes/<test name>.json;
+//   - replay: the traffic saved by an earlier recording.
+//
+// The fake server is returned in every mode so tests can seed it, but only the
+// default mode talks to it. The developer's credentials profile and OAuth2
+// settings are always masked.
+//
+// Without the terraform CLI the test is skipped, or fails when CI is set.
+func testAPI(t *testing.T) *fakeapi.Server {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			// CI must run these tests rather than report a skip as success.
+			if os.Getenv("CI") != "" {
+				t.Fatal("terraform CLI not found: install it or set TF_ACC_TERRAFORM_PATH")
+			}
+			t.Skip("terraform CLI not found: install it or set TF_ACC_TERRAFORM_PATH")
+		}
+	}
+
+	server := fakeapi.New(t)
+	endpoint, token := server.URL, fakeapi.Token
+
+	if v := os.Getenv(envCassette); v != "" {
+		mode, err := thetalake.ParseCassetteMode(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// TestAccReplay runs other tests as subtests of its own.
+		name := strings.TrimPrefix(t.Name(), "TestAccReplay/")
+		file := filepath.Join("testdata", "cassettes", name+".json")
+		if mode == thetalake.CassetteReplay {
+			if _, err := os.Stat(file); err != nil {
+				t.Skipf("no cassette recorded for %s", name)
+			}
+			endpoint = cassetteEndpoint
+		} else {
+			testAccPreCheck(t)
+			endpoint, token = os.Getenv(envEndpoint), os.Getenv(envToken)
+		}
+
+		cassette, err := thetalake.NewCassette(file, mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testCassette = cassette
+		t.Cleanup(func() {
+			testCassette = nil
+			if err := cassette.Save(); err != nil {
+				t.Errorf("saving cassette: %s", err)
+			}
+		})
+	}
+
+	t.Setenv(envEndpoint, endpoint)
+	t.Setenv(envToken, token)
+	t.Setenv(envProfile, "")
+	t.Setenv(envCredentialsFile, filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv(envClientID, "")
+	t.Setenv(envClientSecret, "")
+	t.Setenv(envTokenURL, "")
+
+	return server
+}
+
+// TestAccReplay replays the committed cassettes, so the record and replay
+// path runs without a tenant. Each cassette is recorded by running the test
+// it is named after with THETALAKE_CASSETTE=record.
+func TestAccReplay(t *testing.T) {
+	t.Setenv(envCassette, "replay")
+
+	t.Run("TestAccTagResource", TestAccTagResource)
+}
+
+// testCheckDestroyed verifies that destroying the configuration removed every
+// object from the fake API. It passes trivially when recording or replaying.
+func testCheckDestroyed(server *fakeapi.Server) func(*terraform.State) error {
+	return func(*terraform.State) error {
+		if n := server.Len(); n != 0 {
+			return fmt.Errorf("%d objects left after destroy", n)
+		}
+
+		return nil
+	}
+}
+
+// testCreate calls r.Create directly with a plan built from model, for tests
+// that drive a resource with a mock client instead of through Terraform. The
+// configuration is the plan, so write-only attributes can be set in model.
+func testCreate(t *testing.T, r resource.Resource, model any) resource.CreateResponse {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("building plan: %v", diags)
+	}
+
+	resp := resource.CreateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	r.Create(ctx, resource.CreateRequest{Plan: plan, Config: tfsdk.Config{Schema: s, Raw: plan.Raw}}, &resp)
+
+	return resp
+}
+
+// testUpdate calls r.Update directly with a prior state built from prior and a
+// plan and configuration built from model.
+func testUpdate(t *testing.T, r resource.Resource, prior, model any) resource.UpdateResponse {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	state := tfsdk.State{Schema: s}
+	if diags := state.Set(ctx, prior); diags.HasError() {
+		t.Fatalf("building state: %v", diags)
+	}
+
+	plan := tfsdk.Plan{Schema: s}
+	if diags := plan.Set(ctx, model); diags.HasError() {
+		t.Fatalf("building plan: %v", diags)
+	}
+
+	resp := resource.UpdateResponse{State: state}
+	r.Update(ctx, resource.UpdateRequest{
+		State:  state,
+		Plan:   plan,
+		Config: tfsdk.Config{Schema: s, Raw: plan.Raw},
+	}, &resp)
+
+	return resp
+}
+
+// testDelete calls r.Delete directly with a state built from model.
+func testDelete(t *testing.T, r resource.Resource, model any) resource.DeleteResponse {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("building state: %v", diags)
+	}
+
+	resp := resource.DeleteResponse{State: state}
+	r.Delete(ctx, resource.DeleteRequest{State: state}, &resp)
+
+	return resp
+}
+
+// testRead calls r.Read directly with a state built from model and returns the
+// response, whose state is null if the resource was removed.
+func testRead(t *testing.T, r resource.Resource, model any) resource.ReadResponse {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	if diags := state.Set(ctx, model); diags.HasError() {
+		t.Fatalf("building state: %v", diags)
+	}
+
+	resp := resource.ReadResponse{State: state}
+	r.Read(ctx, resource.ReadRequest{State: state}, &resp)
+
+	return resp
+}
+
+// testImportState calls r.ImportState directly with the given import
+// identifier and returns the response.
+func testImportState(t *testing.T, r resource.ResourceWithImportState, id string) resource.ImportStateResponse {
+	t.Helper()
+
+	ctx := t.Context()
+
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+	s := schemaResp.Schema
+
+	resp := resource.ImportStateResponse{
+		State: tfsdk.State{Schema: s, Raw: tftypes.NewValue(s.Type().TerraformType(ctx), nil)},
+	}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: id}, &resp)
+
+	return resp
+}
+
+func TestProviderConfigureBurstRequiresRate(t *testing.T) {
+	server := fakeapi.New(t)
+
+	tests := []struct {
+		name      string
+		rate      types.Float64
+		wantError bool
+	}{
+		{name: "positive rate", rate: types.Float64Value(5)},
+		{name: "no rate", rate: types.Float64Null(), wantError: true},
+		{name: "zero rate", rate: types.Float64Value(0), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+
+			for _, name := range []string{envEndpoint, envToken, envProfile, envClientID, envClientSecret, envTokenURL} {
+				t.Setenv(name, "")
+			}
+			t.Setenv(envCredentialsFile, filepath.Join(t.TempDir(), "missing"))
+
+			p := New()
+			var schemaResp provider.SchemaResponse
+			p.Schema(ctx, provider.SchemaRequest{}, &schemaResp)
+
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			diags := state.Set(ctx, &ThetaLakeProviderModel{
+				Endpoint:          types.StringValue(server.URL),
+				Token:             types.StringValue(fakeapi.Token),
+				RequestsPerSecond: tt.rate,
+				Burst:             types.Int64Value(10),
+			})
+			if diags.HasError() {
+				t.Fatalf("building config: %v", diags)
+			}
+
+			var resp provider.ConfigureResponse
+			p.Configure(ctx, provider.ConfigureRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("expected error %t, got %v", tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
@@ -2,16 +2,21 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +29,7 @@ func NewCaseResource() resource.Resource {
 
 // CaseResource defines the resource implementation.
 type CaseResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // CaseResourceModel describes the resource data model.
@@ -36,6 +41,7 @@ type CaseResourceModel struct {
 	Visibility  types.String `tfsdk:"visibility"`
 	Description types.String `tfsdk:"description"`
 	Status      types.String `tfsdk:"status"`
+	CloseDate   types.String `tfsdk:"close_date"`
 }
 
 func (r *CaseResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -64,11 +70,21 @@ func (r *CaseResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			},
 			"open_date": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Case Open Date",
+				Computed:            true,
+				MarkdownDescription: "Case Open Date (YYYY-MM-DD). Defaults to the creation date.",
+				Validators: []validator.String{
+					isDate(),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"visibility": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Case Visibility (PUBLIC or PRIVATE)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(caseVisibilities...),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
@@ -77,7 +93,20 @@ func (r *CaseResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"status": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "Case Status (OPEN or CLOSED)",
+				MarkdownDescription: "Case Status (OPEN or CLOSED). Defaults to OPEN for new cases; when omitted, the status is tracked but not managed.",
+				Validators: []validator.String{
+					stringvalidator.OneOf(caseStatuses...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"close_date": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Date the case was closed. Null while the case is open.",
+				PlanModifiers: []planmodifier.String{
+					closeDatePlanModifier{},
+				},
 			},
 		},
 	}
@@ -89,12 +118,12 @@ func (r *CaseResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -113,7 +142,7 @@ func (r *CaseResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	caseReq := client.Case{
+	caseReq := thetalake.Case{
 		Name:        data.Name.ValueString(),
 		Number:      data.Number.ValueString(),
 		OpenDate:    data.OpenDate.ValueString(),
@@ -121,34 +150,38 @@ func (r *CaseResource) Create(ctx context.Context, req resource.CreateRequest, r
 		Description: data.Description.ValueString(),
 	}
 
-	createdCase, err := r.client.CreateCase(caseReq)
+	createdCase, err := r.client.CreateCase(ctx, caseReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create case, got error: %s", err))
 		return
 	}
 
+	plannedStatus := data.Status
+
 	data.ID = types.StringValue(strconv.Itoa(createdCase.ID))
 	data.Name = types.StringValue(createdCase.Name)
 	data.Number = types.StringValue(createdCase.Number)
 	data.OpenDate = types.StringValue(createdCase.OpenDate)
-	data.Visibility = types.StringValue(createdCase.Visibility)
-	data.Description = types.StringValue(createdCase.Description)
-	// Default status is usually OPEN
-	data.Status = types.StringValue("OPEN")
-
-	// If status is specified and different from default, update it
-	if !data.Status.IsNull() && !data.Status.IsUnknown() {
-		if data.Status.ValueString() != "OPEN" {
-			err = r.client.UpdateCaseStatus(strconv.Itoa(createdCase.ID), data.Status.ValueString())
-			if err != nil {
-				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set case status, got error: %s", err))
-				return
-			}
+	data.Visibility = stringOrNull(createdCase.Visibility)
+	data.Description = stringOrNull(createdCase.Description)
+	data.Status = types.StringValue(createdCase.CurrentStatus())
+	data.CloseDate = stringOrNull(createdCase.CloseDate)
+
+	// New cases are open, so closing one takes a second call.
+	if !plannedStatus.IsNull() && !plannedStatus.IsUnknown() && plannedStatus.ValueString() != data.Status.ValueString() {
+		updatedCase, err := r.setStatus(ctx, data.ID.ValueString(), plannedStatus.ValueString())
+		if err != nil {
+			// The case exists, so keep it in state rather than orphaning it.
+			// Terraform marks it tainted and replaces it on the next apply.
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set case status, got error: %s", err))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
 		}
+		data.Status = types.StringValue(updatedCase.CurrentStatus())
+		data.CloseDate = stringOrNull(updatedCase.CloseDate)
 	}
 
-	// Write logs using the tflog package
-	// tflog.Trace(ctx, "created a resource")
+	tflog.Trace(ctx, "created a resource", map[string]any{"id": data.ID.ValueString()})
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -164,7 +197,12 @@ func (r *CaseResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	createdCase, err := r.client.GetCase(data.ID.ValueString())
+	createdCase, err := r.client.GetCase(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read case, got error: %s", err))
 		return
@@ -173,14 +211,10 @@ func (r *CaseResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	data.Name = types.StringValue(createdCase.Name)
 	data.Number = types.StringValue(createdCase.Number)
 	data.OpenDate = types.StringValue(createdCase.OpenDate)
-	data.Visibility = types.StringValue(createdCase.Visibility)
-	data.Description = types.StringValue(createdCase.Description)
-	// We don't have status in GetCase response struct yet, assuming OPEN if not present or need to fetch separately?
-	// For now, let's assume we can't easily read it back without a dedicated field in struct.
-	// But we should probably add Status to Case struct in client if API returns it.
-	// If not, we might rely on state or check open_date/close_date if available.
-	// Let's assume for now we just keep what's in state or default to OPEN.
-	// Ideally, we update Client.Case struct.
+	data.Visibility = stringOrNull(createdCase.Visibility)
+	data.Description = stringOrNull(createdCase.Description)
+	data.Status = types.StringValue(createdCase.CurrentStatus())
+	data.CloseDate = stringOrNull(createdCase.CloseDate)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -195,7 +229,7 @@ func (r *CaseResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		return
 	}
 
-	caseReq := client.Case{
+	caseReq := thetalake.Case{
 		Name:        data.Name.ValueString(),
 		Number:      data.Number.ValueString(),
 		OpenDate:    data.OpenDate.ValueString(),
@@ -203,7 +237,7 @@ func (r *CaseResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		Description: data.Description.ValueString(),
 	}
 
-	updatedCase, err := r.client.UpdateCase(data.ID.ValueString(), caseReq)
+	updatedCase, err := r.client.UpdateCase(ctx, data.ID.ValueString(), caseReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update case, got error: %s", err))
 		return
@@ -212,19 +246,23 @@ func (r *CaseResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	data.Name = types.StringValue(updatedCase.Name)
 	data.Number = types.StringValue(updatedCase.Number)
 	data.OpenDate = types.StringValue(updatedCase.OpenDate)
-	data.Visibility = types.StringValue(updatedCase.Visibility)
-	data.Description = types.StringValue(updatedCase.Description)
-
-	// Handle Status update
-	if !data.Status.IsNull() && !data.Status.IsUnknown() {
-		// Check if status changed
-		// We need prior state to know if it changed, or just apply it.
-		// Applying idempotent open/close is fine.
-		err = r.client.UpdateCaseStatus(data.ID.ValueString(), data.Status.ValueString())
+	data.Visibility = stringOrNull(updatedCase.Visibility)
+	data.Description = stringOrNull(updatedCase.Description)
+
+	plannedStatus := data.Status
+	data.Status = types.StringValue(updatedCase.CurrentStatus())
+	data.CloseDate = stringOrNull(updatedCase.CloseDate)
+
+	if !plannedStatus.IsNull() && !plannedStatus.IsUnknown() && plannedStatus.ValueString() != data.Status.ValueString() {
+		updatedCase, err = r.setStatus(ctx, data.ID.ValueString(), plannedStatus.ValueString())
 		if err != nil {
 			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update case status, got error: %s", err))
+			// The other changes were applied, so record them.
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 			return
 		}
+		data.Status = types.StringValue(updatedCase.CurrentStatus())
+		data.CloseDate = stringOrNull(updatedCase.CloseDate)
 	}
 
 	// Save updated data into Terraform state
@@ -241,13 +279,101 @@ func (r *CaseResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteCase(data.ID.ValueString())
-	if err != nil {
+	err := r.client.DeleteCase(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete case, got error: %s", err))
 		return
 	}
 }
 
+// setStatus opens or closes a case and returns it as it is afterwards, since
+// the open and close calls do not report the close date.
+func (r *CaseResource) setStatus(ctx context.Context, caseID, status string) (*thetalake.Case, error) {
+	if err := r.client.UpdateCaseStatus(ctx, caseID, status); err != nil {
+		return nil, err
+	}
+
+	return r.client.GetCase(ctx, caseID)
+}
+
+// caseNumberImportPrefix marks an import identifier that is a case number
+// rather than an ID, as in "number:CASE-2023-001".
+const caseNumberImportPrefix = "number:"
+
 func (r *CaseResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	number, ok := strings.CutPrefix(req.ID, caseNumberImportPrefix)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if number == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: case_id or number:case_number. Got: %q", req.ID),
+		)
+		return
+	}
+
+	theCase, err := findCaseByNumber(ctx, r.client, number)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find case by number, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(theCase.ID))...)
+}
+
+// findCaseByNumber returns the case with the given number, failing unless
+// exactly one case has it.
+func findCaseByNumber(ctx context.Context, client thetalake.API, number string) (*thetalake.Case, error) {
+	// An empty filter would match every case.
+	if number == "" {
+		return nil, errors.New("case number must not be empty")
+	}
+
+	// Two results are enough to tell that the number is ambiguous.
+	cases, err := client.SearchCases(ctx, thetalake.CaseFilter{Number: number}, thetalake.ListOptions{MaxResults: 2})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(cases) {
+	case 0:
+		return nil, fmt.Errorf("no case has number %q", number)
+	case 1:
+		return &cases[0], nil
+	default:
+		return nil, fmt.Errorf("more than one case has number %q; import it by ID instead", number)
+	}
+}
+
+// closeDatePlanModifier keeps the close date from state unless the status is
+// changing, in which case the API sets it during apply.
+type closeDatePlanModifier struct{}
+
+func (m closeDatePlanModifier) Description(ctx context.Context) string {
+	return "Uses the prior close date unless the status changes."
+}
+
+func (m closeDatePlanModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m closeDatePlanModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// Nothing to keep on create or destroy.
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planned, prior types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("status"), &planned)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("status"), &prior)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if planned.Equal(prior) {
+		resp.PlanValue = req.StateValue
+	}
 }
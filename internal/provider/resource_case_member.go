@@ -0,0 +1,225 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CaseMemberResource{}
+var _ resource.ResourceWithImportState = &CaseMemberResource{}
+var _ resource.ResourceWithConfigValidators = &CaseMemberResource{}
+
+func NewCaseMemberResource() resource.Resource {
+	return &CaseMemberResource{}
+}
+
+// CaseMemberResource defines the resource implementation.
+type CaseMemberResource struct {
+	client thetalake.API
+}
+
+// CaseMemberResourceModel describes the resource data model.
+type CaseMemberResourceModel struct {
+	ID               types.String `tfsdk:"id"`
+	CaseID           types.String `tfsdk:"case_id"`
+	UserID           types.String `tfsdk:"user_id"`
+	DirectoryGroupID types.String `tfsdk:"directory_group_id"`
+	Role             types.String `tfsdk:"role"`
+}
+
+func (r *CaseMemberResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_case_member"
+}
+
+func (r *CaseMemberResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Theta Lake Case Member Resource. Grants a user or a directory group a role on a case.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Case Member ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"case_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Case ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the user to grant access. Exactly one of `user_id` and `directory_group_id` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"directory_group_id": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "ID of the directory group to grant access. Exactly one of `user_id` and `directory_group_id` must be set.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Case role to grant. The API rejects roles it does not define.",
+			},
+		},
+	}
+}
+
+func (r *CaseMemberResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("user_id"),
+			path.MatchRoot("directory_group_id"),
+		),
+	}
+}
+
+func (r *CaseMemberResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(thetalake.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CaseMemberResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CaseMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	memberReq := thetalake.CaseMember{Role: data.Role.ValueString()}
+	memberReq.UserID = parseID(path.Root("user_id"), data.UserID, &resp.Diagnostics)
+	memberReq.DirectoryGroupID = parseID(path.Root("directory_group_id"), data.DirectoryGroupID, &resp.Diagnostics)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.client.CreateCaseMember(ctx, data.CaseID.ValueString(), memberReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add case member, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(member.ID))
+	data.Role = types.StringValue(member.Role)
+
+	tflog.Trace(ctx, "created a resource", map[string]any{"id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CaseMemberResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CaseMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	member, err := r.client.GetCaseMember(ctx, data.CaseID.ValueString(), data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read case member, got error: %s", err))
+		return
+	}
+
+	data.UserID = idOrNull(member.UserID)
+	data.DirectoryGroupID = idOrNull(member.DirectoryGroupID)
+	data.Role = types.StringValue(member.Role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CaseMemberResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CaseMemberResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the role can change in place; everything else forces replacement.
+	member, err := r.client.UpdateCaseMember(ctx, data.CaseID.ValueString(), data.ID.ValueString(), thetalake.CaseMember{
+		Role: data.Role.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update case member, got error: %s", err))
+		return
+	}
+
+	data.Role = types.StringValue(member.Role)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CaseMemberResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CaseMemberResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteCaseMember(ctx, data.CaseID.ValueString(), data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove case member, got error: %s", err))
+		return
+	}
+}
+
+func (r *CaseMemberResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	caseID, memberID, ok := strings.Cut(req.ID, ":")
+	if !ok || caseID == "" || memberID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: case_id:member_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("case_id"), caseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), memberID)...)
+}
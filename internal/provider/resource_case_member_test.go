@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestAccCaseMemberResource(t *testing.T) {
+	server := testAPI(t)
+
+	var memberID string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCaseMemberResourceConfig("VIEWER"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("thetalake_case_member.user", "user_id", "thetalake_user.test", "id"),
+					resource.TestCheckNoResourceAttr("thetalake_case_member.user", "directory_group_id"),
+					resource.TestCheckResourceAttr("thetalake_case_member.user", "role", "VIEWER"),
+					resource.TestCheckResourceAttrPair("thetalake_case_member.group", "directory_group_id", "thetalake_directory_group.test", "id"),
+					resource.TestCheckResourceAttrWith("thetalake_case_member.user", "id", func(id string) error {
+						memberID = id
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "thetalake_case_member.user",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs := s.RootModule().Resources["thetalake_case_member.user"]
+					return rs.Primary.Attributes["case_id"] + ":" + rs.Primary.ID, nil
+				},
+			},
+			// A role changed outside Terraform is detected and reverted.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(memberID)
+					server.SetCaseMemberRole(id, "EDITOR")
+				},
+				Config: testAccCaseMemberResourceConfig("VIEWER"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_case_member.user", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Access revoked outside Terraform is granted again.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(memberID)
+					server.RemoveCaseMember(id)
+				},
+				Config: testAccCaseMemberResourceConfig("VIEWER"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_case_member.user", plancheck.ResourceActionCreate),
+					},
+				},
+			},
+			// Update and Read testing
+			{
+				Config: testAccCaseMemberResourceConfig("EDITOR"),
+				Check:  resource.TestCheckResourceAttr("thetalake_case_member.user", "role", "EDITOR"),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestAccCaseMemberResourceValidation(t *testing.T) {
+	testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "thetalake_case_member" "test" {
+  case_id            = "1"
+  user_id            = "2"
+  directory_group_id = "3"
+  role               = "VIEWER"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Exactly one of these attributes must be configured`),
+			},
+		},
+	})
+}
+
+func testAccCaseMemberResourceConfig(role string) string {
+	return fmt.Sprintf(`
+resource "thetalake_case" "test" {
+  name       = "member-case"
+  number     = "CASE-TEST-005"
+  visibility = "PRIVATE"
+}
+
+resource "thetalake_user" "test" {
+  name                  = "member-user"
+  email                 = "member-user@example.com"
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 1
+}
+
+resource "thetalake_directory_group" "test" {
+  name = "member-group"
+}
+
+resource "thetalake_case_member" "user" {
+  case_id = thetalake_case.test.id
+  user_id = thetalake_user.test.id
+  role    = %[1]q
+}
+
+resource "thetalake_case_member" "group" {
+  case_id            = thetalake_case.test.id
+  directory_group_id = thetalake_directory_group.test.id
+  role               = "VIEWER"
+}
+`, role)
+}
+
+func TestCaseMemberResourceRead(t *testing.T) {
+	for name, tc := range map[string]struct {
+		member  *thetalake.CaseMember
+		err     error
+		removed bool
+	}{
+		"role changed": {member: &thetalake.CaseMember{ID: 7, CaseID: 42, UserID: 3, Role: "EDITOR"}},
+		"revoked":      {err: &thetalake.APIError{StatusCode: http.StatusNotFound}, removed: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockapi.API{
+				GetCaseMemberFunc: func(ctx context.Context, caseID, memberID string) (*thetalake.CaseMember, error) {
+					if caseID != "42" || memberID != "7" {
+						t.Errorf("unexpected member %s:%s", caseID, memberID)
+					}
+					return tc.member, tc.err
+				},
+			}
+
+			resp := testRead(t, &CaseMemberResource{client: api}, &CaseMemberResourceModel{
+				ID:               types.StringValue("7"),
+				CaseID:           types.StringValue("42"),
+				UserID:           types.StringValue("3"),
+				DirectoryGroupID: types.StringNull(),
+				Role:             types.StringValue("VIEWER"),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if resp.State.Raw.IsNull() != tc.removed {
+				t.Fatalf("removed = %t, want %t", resp.State.Raw.IsNull(), tc.removed)
+			}
+			if tc.removed {
+				return
+			}
+
+			var state CaseMemberResourceModel
+			resp.Diagnostics.Append(resp.State.Get(t.Context(), &state)...)
+			if state.Role.ValueString() != "EDITOR" || state.UserID.ValueString() != "3" || !state.DirectoryGroupID.IsNull() {
+				t.Errorf("unexpected state %+v", state)
+			}
+		})
+	}
+}
@@ -3,13 +3,16 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -22,7 +25,7 @@ func NewCaseRecordResource() resource.Resource {
 
 // CaseRecordResource defines the resource implementation.
 type CaseRecordResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // CaseRecordResourceModel describes the resource data model.
@@ -63,12 +66,12 @@ func (r *CaseRecordResource) Configure(ctx context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -86,7 +89,7 @@ func (r *CaseRecordResource) Create(ctx context.Context, req resource.CreateRequ
 		return
 	}
 
-	err := r.client.AddRecordToCase(data.CaseID.ValueString(), data.RecordID.ValueString())
+	err := r.client.AddRecordToCase(ctx, data.CaseID.ValueString(), data.RecordID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to add record to case, got error: %s", err))
 		return
@@ -96,13 +99,36 @@ func (r *CaseRecordResource) Create(ctx context.Context, req resource.CreateRequ
 }
 
 func (r *CaseRecordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
-	// There isn't a direct "get case record" endpoint usually.
-	// We might need to list records in a case and check if this one exists.
-	// For now, we'll assume it exists if it was created, or implement a check if possible.
-	// Since we don't have a "ListCaseRecords" method yet, we'll just pass through.
-	// Ideally, we should verify existence.
+	var data CaseRecordResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// There is no endpoint for a single link, so look for the record among
+	// those in the case.
+	records, err := r.client.GetCaseRecords(ctx, data.CaseID.ValueString(), thetalake.ListOptions{})
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read case records, got error: %s", err))
+		return
+	}
+
+	linked := slices.ContainsFunc(records, func(record thetalake.Record) bool {
+		return record.ID == data.RecordID.ValueString()
+	})
+	if !linked {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 
-	// TODO: Implement verification logic if API supports listing records in a case.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *CaseRecordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -118,15 +144,23 @@ func (r *CaseRecordResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.RemoveRecordFromCase(data.CaseID.ValueString(), data.RecordID.ValueString())
-	if err != nil {
+	err := r.client.RemoveRecordFromCase(ctx, data.CaseID.ValueString(), data.RecordID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove record from case, got error: %s", err))
 		return
 	}
 }
 
 func (r *CaseRecordResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Import needs composite ID (case_id:record_id)
-	// For now, not implementing complex import logic.
-	resp.Diagnostics.AddError("Import Not Supported", "Importing case records is not currently supported.")
+	caseID, recordID, ok := strings.Cut(req.ID, ":")
+	if !ok || caseID == "" || recordID == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: case_id:record_id. Got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("case_id"), caseID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("record_id"), recordID)...)
 }
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestAccCaseRecordResource(t *testing.T) {
+	server := testAPI(t)
+	server.AddRecord(thetalake.Record{ID: "rec-1", ContentDate: "2024-01-01"})
+
+	var caseID string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCaseRecordResourceConfig("rec-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_case_record.test", "record_id", "rec-1"),
+					resource.TestCheckResourceAttrPair("thetalake_case_record.test", "case_id", "thetalake_case.test", "id"),
+					resource.TestCheckResourceAttrWith("thetalake_case.test", "id", func(id string) error {
+						caseID = id
+						return nil
+					}),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:                         "thetalake_case_record.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "case_id",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return caseID + ":rec-1", nil
+				},
+			},
+			// A record unlinked outside Terraform is linked again.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(caseID)
+					server.RemoveCaseRecord(id, "rec-1")
+				},
+				Config: testAccCaseRecordResourceConfig("rec-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_case_record.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: func(*terraform.State) error {
+					id, _ := strconv.Atoi(caseID)
+					if records := server.CaseRecords(id); len(records) != 1 {
+						return fmt.Errorf("unexpected case records %v", records)
+					}
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCaseRecordResourceConfig(recordID string) string {
+	return fmt.Sprintf(`
+resource "thetalake_case" "test" {
+  name   = "record-case"
+  number = "CASE-TEST-003"
+}
+
+resource "thetalake_case_record" "test" {
+  case_id   = thetalake_case.test.id
+  record_id = %[1]q
+}
+`, recordID)
+}
+
+func TestCaseRecordResourceReadRemovesMissingLink(t *testing.T) {
+	for name, records := range map[string][]thetalake.Record{
+		"linked":   {{ID: "rec-0"}, {ID: "rec-1"}},
+		"unlinked": {{ID: "rec-0"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockapi.API{
+				GetCaseRecordsFunc: func(ctx context.Context, caseID string, opts thetalake.ListOptions) ([]thetalake.Record, error) {
+					return records, nil
+				},
+			}
+
+			resp := testRead(t, &CaseRecordResource{client: api}, &CaseRecordResourceModel{
+				CaseID:   types.StringValue("42"),
+				RecordID: types.StringValue("rec-1"),
+			})
+
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if removed := resp.State.Raw.IsNull(); removed != (name == "unlinked") {
+				t.Errorf("removed = %t", removed)
+			}
+		})
+	}
+}
+
+func TestCaseRecordResourceReadRemovesDeletedCase(t *testing.T) {
+	api := &mockapi.API{
+		GetCaseRecordsFunc: func(ctx context.Context, caseID string, opts thetalake.ListOptions) ([]thetalake.Record, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &CaseRecordResource{client: api}, &CaseRecordResourceModel{
+		CaseID:   types.StringValue("42"),
+		RecordID: types.StringValue("rec-1"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the link should be removed from state when the case is gone")
+	}
+}
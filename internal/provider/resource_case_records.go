@@ -0,0 +1,328 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// caseRecordsBatchSize is how many links are added or removed at once. Each
+// batch is applied with up to caseRecordsConcurrency requests in flight, and
+// the provider-wide rate limit still applies.
+const (
+	caseRecordsBatchSize   = 100
+	caseRecordsConcurrency = 8
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &CaseRecordsResource{}
+var _ resource.ResourceWithImportState = &CaseRecordsResource{}
+
+func NewCaseRecordsResource() resource.Resource {
+	return &CaseRecordsResource{}
+}
+
+// CaseRecordsResource defines the resource implementation.
+type CaseRecordsResource struct {
+	client thetalake.API
+}
+
+// CaseRecordsResourceModel describes the resource data model.
+type CaseRecordsResourceModel struct {
+	ID        types.String `tfsdk:"id"`
+	CaseID    types.String `tfsdk:"case_id"`
+	RecordIDs types.Set    `tfsdk:"record_ids"`
+}
+
+func (r *CaseRecordsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_case_records"
+}
+
+func (r *CaseRecordsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Theta Lake Case Records Resource. Authoritatively manages the records in a case: " +
+			"records not listed in `record_ids` are removed from the case. Do not combine it with " +
+			"`thetalake_case_record` for the same case.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Case ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"case_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Case ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"record_ids": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "IDs of the records in the case",
+			},
+		},
+	}
+}
+
+func (r *CaseRecordsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(thetalake.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *CaseRecordsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data CaseRecordsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	data.ID = data.CaseID
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() && data.RecordIDs.IsUnknown() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource", map[string]any{"id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CaseRecordsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data CaseRecordsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.caseRecordIDs(ctx, data.CaseID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read case records, got error: %s", err))
+		return
+	}
+
+	recordIDs, diags := types.SetValueFrom(ctx, types.StringType, current)
+	resp.Diagnostics.Append(diags...)
+	data.RecordIDs = recordIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CaseRecordsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data CaseRecordsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &data)...)
+	if resp.Diagnostics.HasError() && data.RecordIDs.IsUnknown() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *CaseRecordsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data CaseRecordsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var recordIDs []string
+	resp.Diagnostics.Append(data.RecordIDs.ElementsAs(ctx, &recordIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.forEachBatch(ctx, recordIDs, func(ctx context.Context, recordID string) error {
+		err := r.client.RemoveRecordFromCase(ctx, data.CaseID.ValueString(), recordID)
+		if thetalake.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to remove records from case, got error: %s", err))
+		return
+	}
+}
+
+func (r *CaseRecordsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("case_id"), req, resp)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
+
+// apply makes the records in the case match data.RecordIDs, comparing against
+// the membership reported by the API rather than the prior state, so records
+// linked or unlinked outside Terraform are corrected too. On failure it sets
+// data.RecordIDs to the membership left behind, or to unknown if that cannot
+// be read.
+func (r *CaseRecordsResource) apply(ctx context.Context, data *CaseRecordsResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var desired []string
+	diags.Append(data.RecordIDs.ElementsAs(ctx, &desired, false)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	caseID := data.CaseID.ValueString()
+	current, err := r.caseRecordIDs(ctx, caseID)
+	if err != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to read case records, got error: %s", err))
+		data.RecordIDs = types.SetUnknown(types.StringType)
+		return diags
+	}
+
+	add, remove := setDifference(desired, current), setDifference(current, desired)
+
+	tflog.Debug(ctx, "updating case records", map[string]any{"case_id": caseID, "add": len(add), "remove": len(remove)})
+
+	addErr := r.forEachBatch(ctx, add, func(ctx context.Context, recordID string) error {
+		return r.client.AddRecordToCase(ctx, caseID, recordID)
+	})
+	removeErr := r.forEachBatch(ctx, remove, func(ctx context.Context, recordID string) error {
+		err := r.client.RemoveRecordFromCase(ctx, caseID, recordID)
+		if thetalake.IsNotFound(err) {
+			return nil
+		}
+		return err
+	})
+	if addErr == nil && removeErr == nil {
+		return diags
+	}
+
+	if addErr != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to add records to case, got error: %s", addErr))
+	}
+	if removeErr != nil {
+		diags.AddError("Client Error", fmt.Sprintf("Unable to remove records from case, got error: %s", removeErr))
+	}
+
+	// Record what was applied so the next plan only retries the rest.
+	current, err = r.caseRecordIDs(ctx, caseID)
+	if err != nil {
+		data.RecordIDs = types.SetUnknown(types.StringType)
+		return diags
+	}
+
+	recordIDs, d := types.SetValueFrom(ctx, types.StringType, current)
+	diags.Append(d...)
+	data.RecordIDs = recordIDs
+
+	return diags
+}
+
+// caseRecordIDs returns the IDs of the records currently in a case.
+func (r *CaseRecordsResource) caseRecordIDs(ctx context.Context, caseID string) ([]string, error) {
+	records, err := r.client.GetCaseRecords(ctx, caseID, thetalake.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(records))
+	for _, record := range records {
+		ids = append(ids, record.ID)
+	}
+
+	return ids, nil
+}
+
+// setDifference returns the elements of a that are not in b.
+func setDifference(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, v := range b {
+		inB[v] = true
+	}
+
+	var diff []string
+	for _, v := range a {
+		if !inB[v] {
+			diff = append(diff, v)
+		}
+	}
+
+	return diff
+}
+
+// forEachBatch calls fn for every record ID, caseRecordsBatchSize at a time
+// with at most caseRecordsConcurrency calls in flight. A failed batch stops
+// the remaining batches; the errors of that batch are joined.
+func (r *CaseRecordsResource) forEachBatch(ctx context.Context, recordIDs []string, fn func(ctx context.Context, recordID string) error) error {
+	for batch := range slices.Chunk(recordIDs, caseRecordsBatchSize) {
+		var (
+			wg   sync.WaitGroup
+			mu   sync.Mutex
+			errs []error
+		)
+
+		sem := make(chan struct{}, caseRecordsConcurrency)
+		for _, recordID := range batch {
+			sem <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-sem
+					wg.Done()
+				}()
+
+				if err := fn(ctx, recordID); err != nil {
+					mu.Lock()
+					errs = append(errs, fmt.Errorf("record %s: %w", recordID, err))
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(errs) > 0 {
+			return errors.Join(errs...)
+		}
+	}
+
+	return nil
+}
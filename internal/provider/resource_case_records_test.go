@@ -0,0 +1,211 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestAccCaseRecordsResource(t *testing.T) {
+	server := testAPI(t)
+	for i := range 4 {
+		server.AddRecord(thetalake.Record{ID: fmt.Sprintf("rec-%d", i), ContentDate: "2024-01-01"})
+	}
+
+	var caseID string
+	checkMembership := func(want ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			id, _ := strconv.Atoi(caseID)
+			if got := server.CaseRecords(id); !slices.Equal(got, want) {
+				return fmt.Errorf("case records = %v, want %v", got, want)
+			}
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccCaseRecordsResourceConfig("rec-0", "rec-1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrWith("thetalake_case.test", "id", func(id string) error {
+						caseID = id
+						return nil
+					}),
+					resource.TestCheckResourceAttr("thetalake_case_records.test", "record_ids.#", "2"),
+					checkMembership("rec-0", "rec-1"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "thetalake_case_records.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Records linked and unlinked outside Terraform show up as drift.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(caseID)
+					server.RemoveCaseRecord(id, "rec-0")
+					server.AddCaseRecord(id, "rec-3")
+				},
+				Config: testAccCaseRecordsResourceConfig("rec-0", "rec-1"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_case_records.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkMembership("rec-0", "rec-1"),
+			},
+			// Update and Read testing
+			{
+				Config: testAccCaseRecordsResourceConfig("rec-1", "rec-2", "rec-3"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_case_records.test", "record_ids.#", "3"),
+					checkMembership("rec-1", "rec-2", "rec-3"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func testAccCaseRecordsResourceConfig(recordIDs ...string) string {
+	return fmt.Sprintf(`
+resource "thetalake_case" "test" {
+  name   = "records-case"
+  number = "CASE-TEST-004"
+}
+
+resource "thetalake_case_records" "test" {
+  case_id    = thetalake_case.test.id
+  record_ids = ["%[1]s"]
+}
+`, strings.Join(recordIDs, `", "`))
+}
+
+// fakeMembership is a mock case membership that fails to add the records in
+// failAdd.
+type fakeMembership struct {
+	mu      sync.Mutex
+	records map[string]bool
+	failAdd map[string]bool
+}
+
+func (f *fakeMembership) api() *mockapi.API {
+	return &mockapi.API{
+		GetCaseRecordsFunc: func(ctx context.Context, caseID string, opts thetalake.ListOptions) ([]thetalake.Record, error) {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			var records []thetalake.Record
+			for id := range f.records {
+				records = append(records, thetalake.Record{ID: id})
+			}
+			return records, nil
+		},
+		AddRecordToCaseFunc: func(ctx context.Context, caseID, recordID string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			if f.failAdd[recordID] {
+				return errors.New("record is under legal hold elsewhere")
+			}
+			f.records[recordID] = true
+			return nil
+		},
+		RemoveRecordFromCaseFunc: func(ctx context.Context, caseID, recordID string) error {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+			delete(f.records, recordID)
+			return nil
+		},
+	}
+}
+
+func TestCaseRecordsResourceApply(t *testing.T) {
+	membership := &fakeMembership{records: map[string]bool{"keep": true, "drop": true}}
+	for i := range 2 * caseRecordsBatchSize {
+		membership.records[fmt.Sprintf("old-%d", i)] = true
+	}
+
+	want := []string{"keep"}
+	for i := range 2*caseRecordsBatchSize + 1 {
+		want = append(want, fmt.Sprintf("new-%d", i))
+	}
+
+	r := &CaseRecordsResource{client: membership.api()}
+	resp := testCreate(t, r, &CaseRecordsResourceModel{
+		ID:        types.StringUnknown(),
+		CaseID:    types.StringValue("42"),
+		RecordIDs: testStringSet(t, want),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var got []string
+	for id := range membership.records {
+		got = append(got, id)
+	}
+	slices.Sort(got)
+	slices.Sort(want)
+	if !slices.Equal(got, want) {
+		t.Errorf("case records = %v, want %v", got, want)
+	}
+}
+
+func TestCaseRecordsResourceApplyPartialFailure(t *testing.T) {
+	membership := &fakeMembership{
+		records: map[string]bool{"keep": true},
+		failAdd: map[string]bool{"held": true},
+	}
+
+	r := &CaseRecordsResource{client: membership.api()}
+	resp := testCreate(t, r, &CaseRecordsResourceModel{
+		ID:        types.StringUnknown(),
+		CaseID:    types.StringValue("42"),
+		RecordIDs: testStringSet(t, []string{"keep", "new", "held"}),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the failed add to be reported")
+	}
+	if !strings.Contains(resp.Diagnostics.Errors()[0].Detail(), "record held") {
+		t.Errorf("error does not name the record: %s", resp.Diagnostics.Errors()[0].Detail())
+	}
+
+	var state CaseRecordsResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &state)...)
+	var recordIDs []string
+	state.RecordIDs.ElementsAs(t.Context(), &recordIDs, false)
+	slices.Sort(recordIDs)
+	if !slices.Equal(recordIDs, []string{"keep", "new"}) {
+		t.Errorf("state should record the applied membership, got %v", recordIDs)
+	}
+}
+
+func testStringSet(t *testing.T, values []string) types.Set {
+	t.Helper()
+
+	set, diags := types.SetValueFrom(t.Context(), types.StringType, values)
+	if diags.HasError() {
+		t.Fatalf("building set: %v", diags)
+	}
+
+	return set
+}
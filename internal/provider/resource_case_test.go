@@ -1,16 +1,27 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccCaseResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			// Create and Read testing
 			{
@@ -21,6 +32,8 @@ func TestAccCaseResource(t *testing.T) {
 					resource.TestCheckResourceAttr("thetalake_case.test", "visibility", "PRIVATE"),
 					resource.TestCheckResourceAttrSet("thetalake_case.test", "id"),
 					resource.TestCheckResourceAttrSet("thetalake_case.test", "open_date"),
+					resource.TestCheckResourceAttr("thetalake_case.test", "status", "OPEN"),
+					resource.TestCheckNoResourceAttr("thetalake_case.test", "close_date"),
 				),
 			},
 			// ImportState testing
@@ -29,6 +42,12 @@ func TestAccCaseResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "thetalake_case.test",
+				ImportState:       true,
+				ImportStateId:     "number:CASE-TEST-001",
+				ImportStateVerify: true,
+			},
 			// Update and Read testing
 			{
 				Config: testAccCaseResourceConfig("test-case-updated", "CASE-TEST-001", "PUBLIC"),
@@ -51,3 +70,240 @@ resource "thetalake_case" "test" {
 }
 `, name, number, visibility)
 }
+
+func TestAccCaseResourceStatus(t *testing.T) {
+	server := testAPI(t)
+
+	var caseID string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			// A case can be created closed.
+			{
+				Config: testAccCaseResourceStatusConfig("CLOSED"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_case.test", "status", "CLOSED"),
+					resource.TestCheckResourceAttrSet("thetalake_case.test", "close_date"),
+					resource.TestCheckNoResourceAttr("thetalake_case.test", "visibility"),
+					resource.TestCheckResourceAttrWith("thetalake_case.test", "id", func(id string) error {
+						caseID = id
+						return nil
+					}),
+				),
+			},
+			// Reopening the case outside Terraform is detected and reverted.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(caseID)
+					server.SetCaseStatus(id, thetalake.CaseStatusOpen)
+				},
+				Config: testAccCaseResourceStatusConfig("CLOSED"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_case.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_case.test", "status", "CLOSED"),
+					resource.TestCheckResourceAttrSet("thetalake_case.test", "close_date"),
+				),
+			},
+			// Reopening through Terraform clears the close date.
+			{
+				Config: testAccCaseResourceStatusConfig("OPEN"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_case.test", "status", "OPEN"),
+					resource.TestCheckNoResourceAttr("thetalake_case.test", "close_date"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCaseResourceStatusConfig(status string) string {
+	return fmt.Sprintf(`
+resource "thetalake_case" "test" {
+  name   = "status-case"
+  number = "CASE-TEST-002"
+  status = %[1]q
+}
+`, status)
+}
+
+func TestFindCaseByNumber(t *testing.T) {
+	for name, tc := range map[string]struct {
+		number string
+		cases  []thetalake.Case
+		err    string
+	}{
+		"found":     {number: "CASE-2023-001", cases: []thetalake.Case{{ID: 7, Number: "CASE-2023-001"}}},
+		"missing":   {number: "CASE-2023-001", err: `no case has number "CASE-2023-001"`},
+		"ambiguous": {number: "CASE-2023-001", cases: []thetalake.Case{{ID: 7}, {ID: 8}}, err: "more than one case"},
+		"empty":     {number: "", err: "case number must not be empty"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockapi.API{
+				SearchCasesFunc: func(ctx context.Context, filter thetalake.CaseFilter, opts thetalake.ListOptions) ([]thetalake.Case, error) {
+					if filter.Number != "CASE-2023-001" {
+						t.Errorf("unexpected filter %+v", filter)
+					}
+					return tc.cases, nil
+				},
+			}
+
+			got, err := findCaseByNumber(t.Context(), api, tc.number)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil || got.ID != 7 {
+				t.Errorf("unexpected result %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestCaseResourceImportEmptyNumber(t *testing.T) {
+	api := &mockapi.API{}
+
+	resp := testImportState(t, &CaseResource{client: api}, "number:")
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unexpected Import Identifier" {
+		t.Errorf("expected an import identifier error, got %v", resp.Diagnostics)
+	}
+	if calls := api.Calls(); len(calls) != 0 {
+		t.Errorf("expected no API calls, got %v", calls)
+	}
+}
+
+func TestCaseResourceCreateClosed(t *testing.T) {
+	api := &mockapi.API{
+		CreateCaseFunc: func(ctx context.Context, c thetalake.Case) (*thetalake.Case, error) {
+			c.ID = 42
+			c.OpenDate = "2024-01-01"
+			c.Status = thetalake.CaseStatusOpen
+			return &c, nil
+		},
+		UpdateCaseStatusFunc: func(ctx context.Context, caseID, status string) error {
+			if caseID != "42" || status != thetalake.CaseStatusClosed {
+				t.Errorf("unexpected status update %s %s", caseID, status)
+			}
+			return nil
+		},
+		GetCaseFunc: func(ctx context.Context, caseID string) (*thetalake.Case, error) {
+			return &thetalake.Case{ID: 42, Name: "test-case", Number: "CASE-TEST-001", OpenDate: "2024-01-01", CloseDate: "2024-01-02"}, nil
+		},
+	}
+
+	resp := testCreate(t, &CaseResource{client: api}, &CaseResourceModel{
+		ID:          types.StringUnknown(),
+		Name:        types.StringValue("test-case"),
+		Number:      types.StringValue("CASE-TEST-001"),
+		OpenDate:    types.StringUnknown(),
+		Visibility:  types.StringNull(),
+		Description: types.StringNull(),
+		Status:      types.StringValue("CLOSED"),
+		CloseDate:   types.StringUnknown(),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+
+	var state CaseResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &state)...)
+	if state.Status.ValueString() != "CLOSED" || state.CloseDate.ValueString() != "2024-01-02" {
+		t.Errorf("the planned status was not applied: %+v", state)
+	}
+}
+
+func TestCaseResourceCreateStatusFailureKeepsCase(t *testing.T) {
+	api := &mockapi.API{
+		CreateCaseFunc: func(ctx context.Context, c thetalake.Case) (*thetalake.Case, error) {
+			c.ID = 42
+			c.OpenDate = "2024-01-01"
+			return &c, nil
+		},
+		UpdateCaseStatusFunc: func(ctx context.Context, caseID, status string) error {
+			return errors.New("status service unavailable")
+		},
+	}
+
+	resp := testCreate(t, &CaseResource{client: api}, &CaseResourceModel{
+		ID:          types.StringUnknown(),
+		Name:        types.StringValue("test-case"),
+		Number:      types.StringValue("CASE-TEST-001"),
+		OpenDate:    types.StringUnknown(),
+		Visibility:  types.StringValue("PRIVATE"),
+		Description: types.StringNull(),
+		Status:      types.StringValue("CLOSED"),
+		CloseDate:   types.StringUnknown(),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the status update failure to be reported")
+	}
+
+	var state CaseResourceModel
+	resp.Diagnostics.Append(resp.State.Get(t.Context(), &state)...)
+	if state.ID.ValueString() != "42" {
+		t.Errorf("the created case was not kept in state: %+v", state)
+	}
+	if state.Status.ValueString() != "OPEN" {
+		t.Errorf("state should record the actual status, got %s", state.Status)
+	}
+	if api.Called("DeleteCase") {
+		t.Error("the case should be left for Terraform to replace, not deleted")
+	}
+}
+
+func TestCaseResourceCreateFailure(t *testing.T) {
+	api := &mockapi.API{
+		CreateCaseFunc: func(ctx context.Context, c thetalake.Case) (*thetalake.Case, error) {
+			return nil, errors.New("boom")
+		},
+	}
+
+	resp := testCreate(t, &CaseResource{client: api}, &CaseResourceModel{
+		ID:          types.StringUnknown(),
+		Name:        types.StringValue("test-case"),
+		Number:      types.StringValue("CASE-TEST-001"),
+		OpenDate:    types.StringUnknown(),
+		Visibility:  types.StringNull(),
+		Description: types.StringNull(),
+		Status:      types.StringValue("CLOSED"),
+		CloseDate:   types.StringUnknown(),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected the create failure to be reported")
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("no state should be saved when nothing was created")
+	}
+	if api.Called("UpdateCaseStatus") {
+		t.Error("the status must not be set on a case that was not created")
+	}
+}
+
+func TestCaseResourceReadRemovesDeletedCase(t *testing.T) {
+	api := &mockapi.API{
+		GetCaseFunc: func(ctx context.Context, caseID string) (*thetalake.Case, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &CaseResource{client: api}, &CaseResourceModel{
+		ID: types.StringValue("42"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the case should be removed from state when it is deleted")
+	}
+}
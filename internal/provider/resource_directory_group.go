@@ -11,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +24,7 @@ func NewDirectoryGroupResource() resource.Resource {
 
 // DirectoryGroupResource defines the resource implementation.
 type DirectoryGroupResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // DirectoryGroupResourceModel describes the resource data model.
@@ -72,12 +72,12 @@ func (r *DirectoryGroupResource) Configure(ctx context.Context, req resource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -95,13 +95,13 @@ func (r *DirectoryGroupResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	groupReq := client.DirectoryGroup{
+	groupReq := thetalake.DirectoryGroup{
 		Name:        data.Name.ValueString(),
 		ExternalID:  data.ExternalID.ValueString(),
 		Description: data.Description.ValueString(),
 	}
 
-	createdGroup, err := r.client.CreateDirectoryGroup(groupReq)
+	createdGroup, err := r.client.CreateDirectoryGroup(ctx, groupReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create directory group, got error: %s", err))
 		return
@@ -109,8 +109,8 @@ func (r *DirectoryGroupResource) Create(ctx context.Context, req resource.Create
 
 	data.ID = types.StringValue(strconv.Itoa(createdGroup.ID))
 	data.Name = types.StringValue(createdGroup.Name)
-	data.ExternalID = types.StringValue(createdGroup.ExternalID)
-	data.Description = types.StringValue(createdGroup.Description)
+	data.ExternalID = stringOrNull(createdGroup.ExternalID)
+	data.Description = stringOrNull(createdGroup.Description)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -124,15 +124,20 @@ func (r *DirectoryGroupResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
-	createdGroup, err := r.client.GetDirectoryGroup(data.ID.ValueString())
+	createdGroup, err := r.client.GetDirectoryGroup(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read directory group, got error: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(createdGroup.Name)
-	data.ExternalID = types.StringValue(createdGroup.ExternalID)
-	data.Description = types.StringValue(createdGroup.Description)
+	data.ExternalID = stringOrNull(createdGroup.ExternalID)
+	data.Description = stringOrNull(createdGroup.Description)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -146,21 +151,21 @@ func (r *DirectoryGroupResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	groupReq := client.DirectoryGroup{
+	groupReq := thetalake.DirectoryGroup{
 		Name:        data.Name.ValueString(),
 		ExternalID:  data.ExternalID.ValueString(),
 		Description: data.Description.ValueString(),
 	}
 
-	updatedGroup, err := r.client.UpdateDirectoryGroup(data.ID.ValueString(), groupReq)
+	updatedGroup, err := r.client.UpdateDirectoryGroup(ctx, data.ID.ValueString(), groupReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update directory group, got error: %s", err))
 		return
 	}
 
 	data.Name = types.StringValue(updatedGroup.Name)
-	data.ExternalID = types.StringValue(updatedGroup.ExternalID)
-	data.Description = types.StringValue(updatedGroup.Description)
+	data.ExternalID = stringOrNull(updatedGroup.ExternalID)
+	data.Description = stringOrNull(updatedGroup.Description)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -174,8 +179,8 @@ func (r *DirectoryGroupResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	err := r.client.DeleteDirectoryGroup(data.ID.ValueString())
-	if err != nil {
+	err := r.client.DeleteDirectoryGroup(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete directory group, got error: %s", err))
 		return
 	}
@@ -1,16 +1,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccDirectoryGroupResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDirectoryGroupResourceConfig("test-group", "Test Group Description"),
@@ -44,3 +51,22 @@ resource "thetalake_directory_group" "test" {
 }
 `, name, description)
 }
+
+func TestDirectoryGroupResourceReadRemovesDeletedGroup(t *testing.T) {
+	api := &mockapi.API{
+		GetDirectoryGroupFunc: func(ctx context.Context, groupID string) (*thetalake.DirectoryGroup, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &DirectoryGroupResource{client: api}, &DirectoryGroupResourceModel{
+		ID: types.StringValue("42"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the directory group should be removed from state when it is deleted")
+	}
+}
@@ -5,13 +5,16 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +27,7 @@ func NewExportResource() resource.Resource {
 
 // ExportResource defines the resource implementation.
 type ExportResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // ExportResourceModel describes the resource data model.
@@ -57,18 +60,33 @@ func (r *ExportResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			"name": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Export Name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"description": schema.StringAttribute{
 				Optional:            true,
 				MarkdownDescription: "Description",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"query_id": schema.Int64Attribute{
 				Optional:            true,
 				MarkdownDescription: "Query ID to export results from",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"format": schema.StringAttribute{
 				Optional:            true,
-				MarkdownDescription: "Export format (e.g., csv, json)",
+				MarkdownDescription: "Export format (csv or json, case-insensitive)",
+				Validators: []validator.String{
+					stringvalidator.OneOfCaseInsensitive(exportFormats...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"status": schema.StringAttribute{
 				Computed:            true,
@@ -87,12 +105,12 @@ func (r *ExportResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -110,7 +128,7 @@ func (r *ExportResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	exportReq := client.Export{
+	exportReq := thetalake.Export{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 		Format:      data.Format.ValueString(),
@@ -120,7 +138,7 @@ func (r *ExportResource) Create(ctx context.Context, req resource.CreateRequest,
 		exportReq.QueryID = int(data.QueryID.ValueInt64())
 	}
 
-	createdExport, err := r.client.CreateExport(exportReq)
+	createdExport, err := r.client.CreateExport(ctx, exportReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create export, got error: %s", err))
 		return
@@ -151,7 +169,12 @@ func (r *ExportResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	createdExport, err := r.client.GetExport(data.ID.ValueString())
+	createdExport, err := r.client.GetExport(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read export, got error: %s", err))
 		return
@@ -172,14 +195,9 @@ func (r *ExportResource) Read(ctx context.Context, req resource.ReadRequest, res
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// Update is never called: the API cannot modify an export, so every
+// configurable attribute forces a new one to be created.
 func (r *ExportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	// Exports are typically immutable or have limited updates.
-	// For now, we'll assume basic fields can be updated or force new.
-	// Given the nature of exports, it's likely better to force new if params change.
-	// But let's implement basic update for name/desc if API supports it.
-	// If not, the API will error and we can adjust.
-	// Actually, usually exports are "run" once. Updating might just update metadata.
-
 	var data ExportResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
@@ -188,19 +206,6 @@ func (r *ExportResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Assuming no update endpoint exists or it's limited, we might just return error or implement if we knew.
-	// For safety with inferred schema, let's assume we can't update core params and just return state.
-	// Or better, let's assume we can't update and force replacement in schema?
-	// I didn't add RequiresReplace to schema, so Terraform expects Update.
-	// I'll implement a "fake" update that just reads back, or error saying not supported.
-	// Let's try to implement a basic update logic assuming PUT exists (common pattern).
-
-	// NOTE: If PUT /exports/{id} doesn't exist, this will fail.
-	// Given I can't check, I'll assume it doesn't exist for now to be safe and just error or warn.
-	// Actually, better to just error "Update not supported" or similar.
-	// But to be "complete", I'll try to implement it assuming standard CRUD.
-
-	resp.Diagnostics.AddWarning("Update Not Supported", "Updating exports is not fully supported by this provider version.")
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -213,8 +218,8 @@ func (r *ExportResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteExport(data.ID.ValueString())
-	if err != nil {
+	err := r.client.DeleteExport(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete export, got error: %s", err))
 		return
 	}
@@ -1,16 +1,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccExportResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccExportResourceConfig("test-export", "Test Export Description", 456, "CSV"),
@@ -49,3 +56,22 @@ resource "thetalake_export" "test" {
 }
 `, name, description, queryID, format)
 }
+
+func TestExportResourceReadRemovesDeletedExport(t *testing.T) {
+	api := &mockapi.API{
+		GetExportFunc: func(ctx context.Context, exportID string) (*thetalake.Export, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &ExportResource{client: api}, &ExportResourceModel{
+		ID: types.StringValue("42"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the export should be removed from state when it is deleted")
+	}
+}
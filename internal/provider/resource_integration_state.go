@@ -10,7 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -23,7 +23,7 @@ func NewIntegrationStateResource() resource.Resource {
 
 // IntegrationStateResource defines the resource implementation.
 type IntegrationStateResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // IntegrationStateResourceModel describes the resource data model.
@@ -71,12 +71,12 @@ func (r *IntegrationStateResource) Configure(ctx context.Context, req resource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -95,7 +95,7 @@ func (r *IntegrationStateResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// "Creating" this resource just means setting the state for an existing integration
-	updatedState, err := r.client.UpdateIntegrationState(data.IntegrationID.ValueString(), data.Paused.ValueBool())
+	updatedState, err := r.client.UpdateIntegrationState(ctx, data.IntegrationID.ValueString(), data.Paused.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set integration state, got error: %s", err))
 		return
@@ -117,7 +117,12 @@ func (r *IntegrationStateResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
-	state, err := r.client.GetIntegrationState(data.IntegrationID.ValueString())
+	state, err := r.client.GetIntegrationState(ctx, data.IntegrationID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read integration state, got error: %s", err))
 		return
@@ -139,7 +144,7 @@ func (r *IntegrationStateResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
-	updatedState, err := r.client.UpdateIntegrationState(data.IntegrationID.ValueString(), data.Paused.ValueBool())
+	updatedState, err := r.client.UpdateIntegrationState(ctx, data.IntegrationID.ValueString(), data.Paused.ValueBool())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update integration state, got error: %s", err))
 		return
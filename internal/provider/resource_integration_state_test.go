@@ -1,15 +1,21 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccIntegrationStateResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
 			{
@@ -20,9 +26,11 @@ func TestAccIntegrationStateResource(t *testing.T) {
 				),
 			},
 			{
-				ResourceName:      "thetalake_integration_state.test",
-				ImportState:       true,
-				ImportStateVerify: true,
+				ResourceName:                         "thetalake_integration_state.test",
+				ImportState:                          true,
+				ImportStateId:                        "789",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "integration_id",
 			},
 			{
 				Config: testAccIntegrationStateResourceConfig("789", true),
@@ -43,3 +51,22 @@ resource "thetalake_integration_state" "test" {
 }
 `, integrationID, paused)
 }
+
+func TestIntegrationStateResourceReadRemovesDeletedIntegration(t *testing.T) {
+	api := &mockapi.API{
+		GetIntegrationStateFunc: func(ctx context.Context, integrationID string) (*thetalake.IntegrationState, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &IntegrationStateResource{client: api}, &IntegrationStateResourceModel{
+		IntegrationID: types.StringValue("789"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the integration should be removed from state when it is deleted")
+	}
+}
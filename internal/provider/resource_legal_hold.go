@@ -11,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +24,7 @@ func NewLegalHoldResource() resource.Resource {
 
 // LegalHoldResource defines the resource implementation.
 type LegalHoldResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // LegalHoldResourceModel describes the resource data model.
@@ -72,12 +72,12 @@ func (r *LegalHoldResource) Configure(ctx context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -95,7 +95,7 @@ func (r *LegalHoldResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	holdReq := client.LegalHold{
+	holdReq := thetalake.LegalHold{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	}
@@ -104,7 +104,7 @@ func (r *LegalHoldResource) Create(ctx context.Context, req resource.CreateReque
 		holdReq.CaseID = int(data.CaseID.ValueInt64())
 	}
 
-	createdHold, err := r.client.CreateLegalHold(holdReq)
+	createdHold, err := r.client.CreateLegalHold(ctx, holdReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create legal hold, got error: %s", err))
 		return
@@ -132,7 +132,12 @@ func (r *LegalHoldResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	createdHold, err := r.client.GetLegalHold(data.ID.ValueString())
+	createdHold, err := r.client.GetLegalHold(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read legal hold, got error: %s", err))
 		return
@@ -159,7 +164,7 @@ func (r *LegalHoldResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	holdReq := client.LegalHold{
+	holdReq := thetalake.LegalHold{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	}
@@ -168,7 +173,7 @@ func (r *LegalHoldResource) Update(ctx context.Context, req resource.UpdateReque
 		holdReq.CaseID = int(data.CaseID.ValueInt64())
 	}
 
-	updatedHold, err := r.client.UpdateLegalHold(data.ID.ValueString(), holdReq)
+	updatedHold, err := r.client.UpdateLegalHold(ctx, data.ID.ValueString(), holdReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update legal hold, got error: %s", err))
 		return
@@ -195,8 +200,8 @@ func (r *LegalHoldResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	err := r.client.DeleteLegalHold(data.ID.ValueString())
-	if err != nil {
+	err := r.client.DeleteLegalHold(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete legal hold, got error: %s", err))
 		return
 	}
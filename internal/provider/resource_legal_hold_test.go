@@ -1,19 +1,26 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccLegalHoldResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
-				Config: testAccLegalHoldResourceConfig("test-hold", "Test Hold Description", 123), // Assuming case ID 123 exists or mock handles it
+				Config: testAccLegalHoldResourceConfig("test-hold", "Test Hold Description", 123),
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("thetalake_legal_hold.test", "name", "test-hold"),
 					resource.TestCheckResourceAttr("thetalake_legal_hold.test", "description", "Test Hold Description"),
@@ -46,3 +53,22 @@ resource "thetalake_legal_hold" "test" {
 }
 `, name, description, caseID)
 }
+
+func TestLegalHoldResourceReadRemovesDeletedHold(t *testing.T) {
+	api := &mockapi.API{
+		GetLegalHoldFunc: func(ctx context.Context, holdID string) (*thetalake.LegalHold, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &LegalHoldResource{client: api}, &LegalHoldResourceModel{
+		ID: types.StringValue("42"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the legal hold should be removed from state when it is deleted")
+	}
+}
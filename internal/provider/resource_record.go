@@ -4,13 +4,15 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -23,7 +25,7 @@ func NewRecordResource() resource.Resource {
 
 // RecordResource defines the resource implementation.
 type RecordResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // RecordResourceModel describes the resource data model.
@@ -62,7 +64,10 @@ func (r *RecordResource) Schema(ctx context.Context, req resource.SchemaRequest,
 			},
 			"review_state": schema.StringAttribute{
 				Required:            true,
-				MarkdownDescription: "Review state of the record (e.g., reviewed, unreviewed, compliant, non-compliant)",
+				MarkdownDescription: "Review state of the record (reviewed, unreviewed, compliant or non-compliant)",
+				Validators: []validator.String{
+					stringvalidator.OneOf(reviewStates...),
+				},
 			},
 			"comment": schema.StringAttribute{
 				Optional:            true,
@@ -77,12 +82,12 @@ func (r *RecordResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -101,7 +106,7 @@ func (r *RecordResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	// "Creating" this resource means setting the review state for an existing record
-	updatedRecord, err := r.client.UpdateRecordReviewState(data.ID.ValueString(), data.ReviewState.ValueString(), data.Comment.ValueString())
+	updatedRecord, err := r.client.UpdateRecordReviewState(ctx, data.ID.ValueString(), data.ReviewState.ValueString(), data.Comment.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set record review state, got error: %s", err))
 		return
@@ -130,7 +135,12 @@ func (r *RecordResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
-	record, err := r.client.GetRecord(data.ID.ValueString())
+	record, err := r.client.GetRecord(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read record, got error: %s", err))
 		return
@@ -159,7 +169,7 @@ func (r *RecordResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	updatedRecord, err := r.client.UpdateRecordReviewState(data.ID.ValueString(), data.ReviewState.ValueString(), data.Comment.ValueString())
+	updatedRecord, err := r.client.UpdateRecordReviewState(ctx, data.ID.ValueString(), data.ReviewState.ValueString(), data.Comment.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update record review state, got error: %s", err))
 		return
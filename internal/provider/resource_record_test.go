@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestRecordResourceReadRemovesDeletedRecord(t *testing.T) {
+	api := &mockapi.API{
+		GetRecordFunc: func(ctx context.Context, recordID string) (*thetalake.Record, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &RecordResource{client: api}, &RecordResourceModel{
+		ID:           types.StringValue("rec-1"),
+		Participants: types.ListNull(types.StringType),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the record should be removed from state when it is deleted")
+	}
+}
@@ -11,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +24,7 @@ func NewRetentionPolicyResource() resource.Resource {
 
 // RetentionPolicyResource defines the resource implementation.
 type RetentionPolicyResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // RetentionPolicyResourceModel describes the resource data model.
@@ -72,12 +72,12 @@ func (r *RetentionPolicyResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -95,7 +95,7 @@ func (r *RetentionPolicyResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	policyReq := client.RetentionPolicy{
+	policyReq := thetalake.RetentionPolicy{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	}
@@ -104,7 +104,7 @@ func (r *RetentionPolicyResource) Create(ctx context.Context, req resource.Creat
 		policyReq.RetentionPeriodDays = int(data.RetentionPeriodDays.ValueInt64())
 	}
 
-	createdPolicy, err := r.client.CreateRetentionPolicy(policyReq)
+	createdPolicy, err := r.client.CreateRetentionPolicy(ctx, policyReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create retention policy, got error: %s", err))
 		return
@@ -132,7 +132,12 @@ func (r *RetentionPolicyResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
-	createdPolicy, err := r.client.GetRetentionPolicy(data.ID.ValueString())
+	createdPolicy, err := r.client.GetRetentionPolicy(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read retention policy, got error: %s", err))
 		return
@@ -159,7 +164,7 @@ func (r *RetentionPolicyResource) Update(ctx context.Context, req resource.Updat
 		return
 	}
 
-	policyReq := client.RetentionPolicy{
+	policyReq := thetalake.RetentionPolicy{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	}
@@ -168,7 +173,7 @@ func (r *RetentionPolicyResource) Update(ctx context.Context, req resource.Updat
 		policyReq.RetentionPeriodDays = int(data.RetentionPeriodDays.ValueInt64())
 	}
 
-	updatedPolicy, err := r.client.UpdateRetentionPolicy(data.ID.ValueString(), policyReq)
+	updatedPolicy, err := r.client.UpdateRetentionPolicy(ctx, data.ID.ValueString(), policyReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update retention policy, got error: %s", err))
 		return
@@ -195,8 +200,8 @@ func (r *RetentionPolicyResource) Delete(ctx context.Context, req resource.Delet
 		return
 	}
 
-	err := r.client.DeleteRetentionPolicy(data.ID.ValueString())
-	if err != nil {
+	err := r.client.DeleteRetentionPolicy(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete retention policy, got error: %s", err))
 		return
 	}
@@ -1,16 +1,23 @@
 package provider
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccRetentionPolicyResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRetentionPolicyResourceConfig("test-policy", "Test Policy Description", 365),
@@ -47,3 +54,22 @@ resource "thetalake_retention_policy" "test" {
 }
 `, name, description, days)
 }
+
+func TestRetentionPolicyResourceReadRemovesDeletedPolicy(t *testing.T) {
+	api := &mockapi.API{
+		GetRetentionPolicyFunc: func(ctx context.Context, policyID string) (*thetalake.RetentionPolicy, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &RetentionPolicyResource{client: api}, &RetentionPolicyResourceModel{
+		ID: types.StringValue("42"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the retention policy should be removed from state when it is deleted")
+	}
+}
@@ -0,0 +1,220 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &RoleResource{}
+var _ resource.ResourceWithImportState = &RoleResource{}
+
+func NewRoleResource() resource.Resource {
+	return &RoleResource{}
+}
+
+// RoleResource defines the resource implementation.
+type RoleResource struct {
+	client thetalake.API
+}
+
+// RoleResourceModel describes the resource data model.
+type RoleResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Permissions types.Set    `tfsdk:"permissions"`
+}
+
+func (r *RoleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_role"
+}
+
+func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Theta Lake Role Resource. Manages a custom role; use the `thetalake_roles` data source to look up built-in roles.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Role ID, for use as a user's `role_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Role Name",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Description",
+			},
+			"permissions": schema.SetAttribute{
+				Required:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Permissions granted by the role",
+			},
+		},
+	}
+}
+
+func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(thetalake.API)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleReq := thetalake.Role{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	}
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &roleReq.Permissions, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	createdRole, err := r.client.CreateRole(ctx, roleReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create role, got error: %s", err))
+		return
+	}
+
+	data.ID = types.StringValue(strconv.Itoa(createdRole.ID))
+	data.Name = types.StringValue(createdRole.Name)
+	data.Description = stringOrNull(createdRole.Description)
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, createdRole.Permissions)
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = permissions
+
+	tflog.Trace(ctx, "created a resource", map[string]any{"id": data.ID.ValueString()})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.GetRole(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read role, got error: %s", err))
+		return
+	}
+
+	if role.BuiltIn {
+		resp.Diagnostics.AddError(
+			"Built-in Role",
+			fmt.Sprintf("Role %d (%s) is built in and cannot be managed. Look it up with the thetalake_roles data source instead.", role.ID, role.Name),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(role.Name)
+	data.Description = stringOrNull(role.Description)
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, role.Permissions)
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = permissions
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	roleReq := thetalake.Role{
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+	}
+	resp.Diagnostics.Append(data.Permissions.ElementsAs(ctx, &roleReq.Permissions, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updatedRole, err := r.client.UpdateRole(ctx, data.ID.ValueString(), roleReq)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update role, got error: %s", err))
+		return
+	}
+
+	data.Name = types.StringValue(updatedRole.Name)
+	data.Description = stringOrNull(updatedRole.Description)
+
+	permissions, diags := types.SetValueFrom(ctx, types.StringType, updatedRole.Permissions)
+	resp.Diagnostics.Append(diags...)
+	data.Permissions = permissions
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *RoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data RoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteRole(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete role, got error: %s", err))
+		return
+	}
+}
+
+func (r *RoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func TestAccRoleResource(t *testing.T) {
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRoleResourceConfig("reviewer", `"records:read", "records:review"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_role.test", "name", "reviewer"),
+					resource.TestCheckResourceAttr("thetalake_role.test", "permissions.#", "2"),
+					resource.TestCheckTypeSetElemAttr("thetalake_role.test", "permissions.*", "records:review"),
+					resource.TestCheckResourceAttrSet("thetalake_role.test", "id"),
+					resource.TestCheckResourceAttrPair("thetalake_user.test", "role_id", "thetalake_role.test", "id"),
+				),
+			},
+			{
+				ResourceName:      "thetalake_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccRoleResourceConfig("senior-reviewer", `"records:read", "records:review", "cases:manage"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_role.test", "name", "senior-reviewer"),
+					resource.TestCheckResourceAttr("thetalake_role.test", "permissions.#", "3"),
+					resource.TestCheckTypeSetElemAttr("thetalake_role.test", "permissions.*", "cases:manage"),
+				),
+			},
+		},
+	})
+}
+
+func TestRoleResourceReadBuiltIn(t *testing.T) {
+	api := &mockapi.API{
+		GetRoleFunc: func(ctx context.Context, roleID string) (*thetalake.Role, error) {
+			return &thetalake.Role{ID: 1, Name: "Administrator", Permissions: []string{"*"}, BuiltIn: true}, nil
+		},
+	}
+
+	resp := testRead(t, &RoleResource{client: api}, &RoleResourceModel{
+		ID:          types.StringValue("1"),
+		Name:        types.StringNull(),
+		Description: types.StringNull(),
+		Permissions: types.SetNull(types.StringType),
+	})
+
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error for a built-in role")
+	}
+	if got := resp.Diagnostics.Errors()[0].Summary(); got != "Built-in Role" {
+		t.Errorf("unexpected error %q", got)
+	}
+}
+
+func testAccRoleResourceConfig(name, permissions string) string {
+	return fmt.Sprintf(`
+resource "thetalake_role" "test" {
+  name        = %[1]q
+  description = "Reviews flagged records"
+  permissions = [%[2]s]
+}
+
+resource "thetalake_user" "test" {
+  name                  = "reviewer"
+  email                 = "reviewer@example.com"
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = thetalake_role.test.id
+}
+`, name, permissions)
+}
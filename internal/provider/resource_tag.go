@@ -11,7 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
@@ -24,7 +24,7 @@ func NewTagResource() resource.Resource {
 
 // TagResource defines the resource implementation.
 type TagResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // TagResourceModel describes the resource data model.
@@ -67,12 +67,12 @@ func (r *TagResource) Configure(ctx context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -90,12 +90,12 @@ func (r *TagResource) Create(ctx context.Context, req resource.CreateRequest, re
 		return
 	}
 
-	tagReq := client.Tag{
+	tagReq := thetalake.Tag{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	}
 
-	createdTag, err := r.client.CreateTag(tagReq)
+	createdTag, err := r.client.CreateTag(ctx, tagReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create tag, got error: %s", err))
 		return
@@ -117,7 +117,12 @@ func (r *TagResource) Read(ctx context.Context, req resource.ReadRequest, resp *
 		return
 	}
 
-	createdTag, err := r.client.GetTag(data.ID.ValueString())
+	createdTag, err := r.client.GetTag(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read tag, got error: %s", err))
 		return
@@ -138,12 +143,12 @@ func (r *TagResource) Update(ctx context.Context, req resource.UpdateRequest, re
 		return
 	}
 
-	tagReq := client.Tag{
+	tagReq := thetalake.Tag{
 		Name:        data.Name.ValueString(),
 		Description: data.Description.ValueString(),
 	}
 
-	updatedTag, err := r.client.UpdateTag(data.ID.ValueString(), tagReq)
+	updatedTag, err := r.client.UpdateTag(ctx, data.ID.ValueString(), tagReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update tag, got error: %s", err))
 		return
@@ -164,8 +169,8 @@ func (r *TagResource) Delete(ctx context.Context, req resource.DeleteRequest, re
 		return
 	}
 
-	err := r.client.DeleteTag(data.ID.ValueString())
-	if err != nil {
+	err := r.client.DeleteTag(ctx, data.ID.ValueString())
+	if err != nil && !thetalake.IsNotFound(err) {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete tag, got error: %s", err))
 		return
 	}
@@ -2,22 +2,39 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
-	"github.com/radugheorghies/thetalake-terraform-provider/internal/client"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
+// What destroying a thetalake_user does to the account.
+const (
+	userDeletionModeDelete     = "delete"
+	userDeletionModeDeactivate = "deactivate"
+	userDeletionModeAbandon    = "abandon"
+)
+
+var userDeletionModes = []string{userDeletionModeDelete, userDeletionModeDeactivate, userDeletionModeAbandon}
+
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &UserResource{}
 var _ resource.ResourceWithImportState = &UserResource{}
+var _ resource.ResourceWithConfigValidators = &UserResource{}
 
 func NewUserResource() resource.Resource {
 	return &UserResource{}
@@ -25,7 +42,7 @@ func NewUserResource() resource.Resource {
 
 // UserResource defines the resource implementation.
 type UserResource struct {
-	client *client.Client
+	client thetalake.API
 }
 
 // UserResourceModel describes the resource data model.
@@ -35,8 +52,11 @@ type UserResourceModel struct {
 	Email                types.String `tfsdk:"email"`
 	Password             types.String `tfsdk:"password"`
 	PasswordConfirmation types.String `tfsdk:"password_confirmation"`
+	PasswordVersion      types.Int64  `tfsdk:"password_version"`
 	RoleID               types.Int64  `tfsdk:"role_id"`
 	SearchID             types.Int64  `tfsdk:"search_id"`
+	Active               types.Bool   `tfsdk:"active"`
+	DeletionMode         types.String `tfsdk:"deletion_mode"`
 }
 
 func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -45,7 +65,8 @@ func (r *UserResource) Metadata(ctx context.Context, req resource.MetadataReques
 
 func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Theta Lake User Resource",
+		MarkdownDescription: "Theta Lake User Resource. The passwords are write-only: they are sent to the API but never " +
+			"stored in the plan or state, which requires Terraform 1.11 or later. Change `password_version` to set a new password.",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
@@ -62,22 +83,25 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"email": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "User Email",
+				Validators: []validator.String{
+					isEmail(),
+				},
 			},
 			"password": schema.StringAttribute{
 				Required:            true,
 				Sensitive:           true,
-				MarkdownDescription: "User Password",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(), // Force new if password changes as update might not support it
-				},
+				WriteOnly:           true,
+				MarkdownDescription: "User Password. Only sent on creation and when `password_version` changes.",
 			},
 			"password_confirmation": schema.StringAttribute{
 				Required:            true,
 				Sensitive:           true,
-				MarkdownDescription: "User Password Confirmation",
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
+				WriteOnly:           true,
+				MarkdownDescription: "User Password Confirmation. Must equal `password`.",
+			},
+			"password_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Arbitrary version of the password. Changing it sets the user's password to `password` in place.",
 			},
 			"role_id": schema.Int64Attribute{
 				Required:            true,
@@ -90,21 +114,45 @@ func (r *UserResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 					int64planmodifier.UseStateForUnknown(),
 				},
 			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the user can sign in. New users are active; when omitted, the flag is tracked but not managed.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_mode": schema.StringAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "What destroying the resource does to the user: `delete` erases the account, `deactivate` " +
+					"disables it so past actions stay attributable, and `abandon` leaves it untouched. Defaults to `delete`.",
+				Default: stringdefault.StaticString(userDeletionModeDelete),
+				Validators: []validator.String{
+					stringvalidator.OneOf(userDeletionModes...),
+				},
+			},
 		},
 	}
 }
 
+func (r *UserResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		stringsEqual(path.Root("password"), path.Root("password_confirmation")),
+	}
+}
+
 func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*client.Client)
+	client, ok := req.ProviderData.(thetalake.API)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *client.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected thetalake.API, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -114,19 +162,21 @@ func (r *UserResource) Configure(ctx context.Context, req resource.ConfigureRequ
 }
 
 func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data UserResourceModel
+	var data, config UserResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	userReq := client.User{
+	// Write-only values are only available in the configuration.
+	userReq := thetalake.User{
 		Name:                 data.Name.ValueString(),
 		Email:                data.Email.ValueString(),
-		Password:             data.Password.ValueString(),
-		PasswordConfirmation: data.PasswordConfirmation.ValueString(),
+		Password:             config.Password.ValueString(),
+		PasswordConfirmation: config.PasswordConfirmation.ValueString(),
 		RoleID:               int(data.RoleID.ValueInt64()),
 	}
 
@@ -134,7 +184,7 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		userReq.SearchID = int(data.SearchID.ValueInt64())
 	}
 
-	createdUser, err := r.client.CreateUser(userReq)
+	createdUser, err := r.client.CreateUser(ctx, userReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create user, got error: %s", err))
 		return
@@ -151,8 +201,22 @@ func (r *UserResource) Create(ctx context.Context, req resource.CreateRequest, r
 		data.SearchID = types.Int64Null()
 	}
 
-	// Password fields are not returned by API, keep them from plan
-	// data.Password and data.PasswordConfirmation are already set from plan
+	plannedActive := data.Active
+	data.Active = types.BoolValue(createdUser.IsActive())
+
+	// New users are active, so creating a disabled one takes a second call.
+	if !plannedActive.IsNull() && !plannedActive.IsUnknown() && !plannedActive.Equal(data.Active) {
+		updatedUser, err := r.setActive(ctx, data.ID.ValueString(), plannedActive.ValueBool())
+		if err != nil {
+			// The user exists, so keep it in state rather than orphaning it.
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable user, got error: %s", err))
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			return
+		}
+		data.Active = types.BoolValue(updatedUser.IsActive())
+	}
+
+	tflog.Trace(ctx, "created a resource", map[string]any{"id": data.ID.ValueString()})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -166,7 +230,12 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	createdUser, err := r.client.GetUser(data.ID.ValueString())
+	createdUser, err := r.client.GetUser(ctx, data.ID.ValueString())
+	if thetalake.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read user, got error: %s", err))
 		return
@@ -182,21 +251,29 @@ func (r *UserResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		data.SearchID = types.Int64Null()
 	}
 
-	// Passwords are not returned, so we don't update them in state from read
+	data.Active = types.BoolValue(createdUser.IsActive())
+
+	// Imported users, and users created by earlier provider versions, have no
+	// deletion mode yet.
+	if data.DeletionMode.IsNull() {
+		data.DeletionMode = types.StringValue(userDeletionModeDelete)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data UserResourceModel
+	var data, state, config UserResourceModel
 
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	userReq := client.User{
+	userReq := thetalake.User{
 		Name:   data.Name.ValueString(),
 		Email:  data.Email.ValueString(),
 		RoleID: int(data.RoleID.ValueInt64()),
@@ -206,7 +283,7 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		userReq.SearchID = int(data.SearchID.ValueInt64())
 	}
 
-	updatedUser, err := r.client.UpdateUser(data.ID.ValueString(), userReq)
+	updatedUser, err := r.client.UpdateUser(ctx, data.ID.ValueString(), userReq)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user, got error: %s", err))
 		return
@@ -222,6 +299,27 @@ func (r *UserResource) Update(ctx context.Context, req resource.UpdateRequest, r
 		data.SearchID = types.Int64Null()
 	}
 
+	plannedActive := data.Active
+	data.Active = types.BoolValue(updatedUser.IsActive())
+
+	if !plannedActive.IsNull() && !plannedActive.IsUnknown() && !plannedActive.Equal(data.Active) {
+		updatedUser, err := r.setActive(ctx, data.ID.ValueString(), plannedActive.ValueBool())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update user status, got error: %s", err))
+		} else {
+			data.Active = types.BoolValue(updatedUser.IsActive())
+		}
+	}
+
+	if !data.PasswordVersion.Equal(state.PasswordVersion) {
+		err := r.client.SetUserPassword(ctx, data.ID.ValueString(), config.Password.ValueString(), config.PasswordConfirmation.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change user password, got error: %s", err))
+			// Keep the old version so the next apply retries the change.
+			data.PasswordVersion = state.PasswordVersion
+		}
+	}
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
@@ -234,13 +332,90 @@ func (r *UserResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 		return
 	}
 
-	err := r.client.DeleteUser(data.ID.ValueString())
+	switch data.DeletionMode.ValueString() {
+	case userDeletionModeAbandon:
+		tflog.Debug(ctx, "abandoning user", map[string]any{"id": data.ID.ValueString()})
+
+	case userDeletionModeDeactivate:
+		err := r.client.DisableUser(ctx, data.ID.ValueString())
+		if err != nil && !thetalake.IsNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to disable user, got error: %s", err))
+			return
+		}
+
+	default:
+		err := r.client.DeleteUser(ctx, data.ID.ValueString())
+		if err != nil && !thetalake.IsNotFound(err) {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+			return
+		}
+	}
+}
+
+// userEmailImportPrefix marks an import identifier that is an email address
+// rather than an ID, as in "email:jane.doe@example.com".
+const userEmailImportPrefix = "email:"
+
+func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	email, ok := strings.CutPrefix(req.ID, userEmailImportPrefix)
+	if !ok {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	if email == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: user_id or email:address. Got: %q", req.ID),
+		)
+		return
+	}
+
+	user, err := findUserByEmail(ctx, r.client, email)
 	if err != nil {
-		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete user, got error: %s", err))
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to find user by email, got error: %s", err))
 		return
 	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), strconv.Itoa(user.ID))...)
 }
 
-func (r *UserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+// findUserByEmail returns the user with the given email address, failing
+// unless exactly one user has it.
+func findUserByEmail(ctx context.Context, client thetalake.API, email string) (*thetalake.User, error) {
+	// An empty filter would match every user.
+	if email == "" {
+		return nil, errors.New("email must not be empty")
+	}
+
+	// Two results are enough to tell that the email is ambiguous.
+	users, err := client.SearchUsers(ctx, thetalake.UserFilter{Email: email}, thetalake.ListOptions{MaxResults: 2})
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(users) {
+	case 0:
+		return nil, fmt.Errorf("no user has email %q", email)
+	case 1:
+		return &users[0], nil
+	default:
+		return nil, fmt.Errorf("more than one user has email %q; use the user ID instead", email)
+	}
+}
+
+// setActive enables or disables a user and returns it as it is afterwards,
+// since the enable and disable calls do not return the user.
+func (r *UserResource) setActive(ctx context.Context, userID string, active bool) (*thetalake.User, error) {
+	var err error
+	if active {
+		err = r.client.EnableUser(ctx, userID)
+	} else {
+		err = r.client.DisableUser(ctx, userID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.client.GetUser(ctx, userID)
 }
@@ -1,16 +1,29 @@
 package provider
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/radugheorghies/thetalake-terraform-provider/internal/mockapi"
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
 )
 
 func TestAccUserResource(t *testing.T) {
-	resource.Test(t, resource.TestCase{
-		PreCheck:                 func() { testAccPreCheck(t) },
+	server := testAPI(t)
+
+	resource.UnitTest(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
 		Steps: []resource.TestStep{
 			{
 				Config: testAccUserResourceConfig("test-user", "test@example.com"),
@@ -18,6 +31,8 @@ func TestAccUserResource(t *testing.T) {
 					resource.TestCheckResourceAttr("thetalake_user.test", "name", "test-user"),
 					resource.TestCheckResourceAttr("thetalake_user.test", "email", "test@example.com"),
 					resource.TestCheckResourceAttrSet("thetalake_user.test", "id"),
+					resource.TestCheckNoResourceAttr("thetalake_user.test", "password"),
+					resource.TestCheckNoResourceAttr("thetalake_user.test", "password_confirmation"),
 				),
 			},
 			{
@@ -25,6 +40,12 @@ func TestAccUserResource(t *testing.T) {
 				ImportState:       true,
 				ImportStateVerify: true,
 			},
+			{
+				ResourceName:      "thetalake_user.test",
+				ImportState:       true,
+				ImportStateId:     "email:TEST@example.com",
+				ImportStateVerify: true,
+			},
 			{
 				Config: testAccUserResourceConfig("test-user-updated", "test-updated@example.com"),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -36,11 +57,291 @@ func TestAccUserResource(t *testing.T) {
 	})
 }
 
+func TestAccUserResourcePasswordRotation(t *testing.T) {
+	server := testAPI(t)
+
+	var userID string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testCheckDestroyed(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourcePasswordConfig("Correct-Horse-1", 1),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_user.test", "password_version", "1"),
+					resource.TestCheckResourceAttrWith("thetalake_user.test", "id", func(id string) error {
+						userID = id
+						return nil
+					}),
+				),
+			},
+			{
+				// A new password alone is not detected; the version drives the change.
+				Config:   testAccUserResourcePasswordConfig("Battery-Staple-2", 1),
+				PlanOnly: true,
+			},
+			// Bumping the version changes the password without replacing the user.
+			{
+				Config: testAccUserResourcePasswordConfig("Battery-Staple-2", 2),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_user.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_user.test", "password_version", "2"),
+					func(*terraform.State) error {
+						id, _ := strconv.Atoi(userID)
+						if n := server.PasswordChanges(id); n != 1 {
+							return fmt.Errorf("got %d password changes, want 1", n)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccUserResourceDeactivate(t *testing.T) {
+	server := testAPI(t)
+
+	var userID string
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		// Destroying deactivates the user instead of deleting it.
+		CheckDestroy: func(*terraform.State) error {
+			id, _ := strconv.Atoi(userID)
+			if server.UserActive(id) || server.Len() != 1 {
+				return fmt.Errorf("expected user %s to be kept and disabled", userID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserResourceActiveConfig("deactivate", true),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("thetalake_user.test", "active", "true"),
+					resource.TestCheckResourceAttr("thetalake_user.test", "deletion_mode", "deactivate"),
+					resource.TestCheckResourceAttrWith("thetalake_user.test", "id", func(id string) error {
+						userID = id
+						return nil
+					}),
+				),
+			},
+			// Disabling the user outside Terraform is detected and reverted.
+			{
+				PreConfig: func() {
+					id, _ := strconv.Atoi(userID)
+					server.SetUserActive(id, false)
+				},
+				Config: testAccUserResourceActiveConfig("deactivate", true),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("thetalake_user.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.TestCheckResourceAttr("thetalake_user.test", "active", "true"),
+			},
+			{
+				Config: testAccUserResourceActiveConfig("deactivate", false),
+				Check:  resource.TestCheckResourceAttr("thetalake_user.test", "active", "false"),
+			},
+			{
+				ResourceName:            "thetalake_user.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"deletion_mode"},
+			},
+		},
+	})
+}
+
+func TestUserResourceDeletionMode(t *testing.T) {
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{"delete", []string{"DeleteUser"}},
+		{"deactivate", []string{"DisableUser"}},
+		{"abandon", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			api := &mockapi.API{
+				DeleteUserFunc: func(ctx context.Context, userID string) error {
+					return nil
+				},
+				DisableUserFunc: func(ctx context.Context, userID string) error {
+					return nil
+				},
+			}
+
+			resp := testDelete(t, &UserResource{client: api}, &UserResourceModel{
+				ID:           types.StringValue("42"),
+				Name:         types.StringValue("jane"),
+				Email:        types.StringValue("jane.doe@example.com"),
+				RoleID:       types.Int64Value(1),
+				Active:       types.BoolValue(true),
+				DeletionMode: types.StringValue(tt.mode),
+			})
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", resp.Diagnostics)
+			}
+			if got := api.Calls(); !slices.Equal(got, tt.want) {
+				t.Errorf("got calls %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindUserByEmail(t *testing.T) {
+	for name, tc := range map[string]struct {
+		email string
+		users []thetalake.User
+		err   string
+	}{
+		"found":     {email: "jane.doe@example.com", users: []thetalake.User{{ID: 7, Email: "jane.doe@example.com"}}},
+		"missing":   {email: "jane.doe@example.com", err: `no user has email "jane.doe@example.com"`},
+		"ambiguous": {email: "jane.doe@example.com", users: []thetalake.User{{ID: 7}, {ID: 8}}, err: "more than one user"},
+		"empty":     {email: "", err: "email must not be empty"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			api := &mockapi.API{
+				SearchUsersFunc: func(ctx context.Context, filter thetalake.UserFilter, opts thetalake.ListOptions) ([]thetalake.User, error) {
+					if filter.Email != "jane.doe@example.com" {
+						t.Errorf("unexpected filter %+v", filter)
+					}
+					return tc.users, nil
+				},
+			}
+
+			got, err := findUserByEmail(t.Context(), api, tc.email)
+			if tc.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Errorf("expected error %q, got %v", tc.err, err)
+				}
+				return
+			}
+			if err != nil || got.ID != 7 {
+				t.Errorf("unexpected result %+v, %v", got, err)
+			}
+		})
+	}
+}
+
+func TestUserResourceImportEmptyEmail(t *testing.T) {
+	api := &mockapi.API{}
+
+	resp := testImportState(t, &UserResource{client: api}, "email:")
+
+	if !resp.Diagnostics.HasError() || resp.Diagnostics.Errors()[0].Summary() != "Unexpected Import Identifier" {
+		t.Errorf("expected an import identifier error, got %v", resp.Diagnostics)
+	}
+	if calls := api.Calls(); len(calls) != 0 {
+		t.Errorf("expected no API calls, got %v", calls)
+	}
+}
+
+func TestUserResourcePasswordRotationFailure(t *testing.T) {
+	api := &mockapi.API{
+		UpdateUserFunc: func(ctx context.Context, userID string, user thetalake.User) (*thetalake.User, error) {
+			user.ID = 42
+			return &user, nil
+		},
+		SetUserPasswordFunc: func(ctx context.Context, userID, password, passwordConfirmation string) error {
+			if password != "Battery-Staple-2" {
+				t.Errorf("got password %q, want the configured one", password)
+			}
+			return errors.New("password service unavailable")
+		},
+	}
+
+	prior := UserResourceModel{
+		ID:                   types.StringValue("42"),
+		Name:                 types.StringValue("jane"),
+		Email:                types.StringValue("jane.doe@example.com"),
+		Password:             types.StringNull(),
+		PasswordConfirmation: types.StringNull(),
+		PasswordVersion:      types.Int64Value(1),
+		RoleID:               types.Int64Value(1),
+		SearchID:             types.Int64Null(),
+	}
+	planned := prior
+	planned.Password = types.StringValue("Battery-Staple-2")
+	planned.PasswordConfirmation = types.StringValue("Battery-Staple-2")
+	planned.PasswordVersion = types.Int64Value(2)
+
+	resp := testUpdate(t, &UserResource{client: api}, &prior, &planned)
+	if !resp.Diagnostics.HasError() {
+		t.Fatal("expected an error")
+	}
+	if got := api.Calls(); !slices.Contains(got, "SetUserPassword") {
+		t.Fatalf("password was not changed: %v", got)
+	}
+
+	var state UserResourceModel
+	resp.State.Get(t.Context(), &state)
+	if state.PasswordVersion.ValueInt64() != 1 {
+		t.Errorf("got password_version %v, want the prior version so the change is retried", state.PasswordVersion)
+	}
+}
+
+func testAccUserResourcePasswordConfig(password string, version int) string {
+	return fmt.Sprintf(`
+resource "thetalake_user" "test" {
+  name                  = "test-user"
+  email                 = "test@example.com"
+  password              = %[1]q
+  password_confirmation = %[1]q
+  password_version      = %[2]d
+  role_id               = 1
+}
+`, password, version)
+}
+
+func testAccUserResourceActiveConfig(deletionMode string, active bool) string {
+	return fmt.Sprintf(`
+resource "thetalake_user" "test" {
+  name                  = "reviewer"
+  email                 = "reviewer@example.com"
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 1
+  active                = %[2]t
+  deletion_mode         = %[1]q
+}
+`, deletionMode, active)
+}
+
 func testAccUserResourceConfig(name, email string) string {
 	return fmt.Sprintf(`
 resource "thetalake_user" "test" {
-  name  = %[1]q
-  email = %[2]q
+  name                  = %[1]q
+  email                 = %[2]q
+  password              = "Correct-Horse-1"
+  password_confirmation = "Correct-Horse-1"
+  role_id               = 1
 }
 `, name, email)
 }
+
+func TestUserResourceReadRemovesDeletedUser(t *testing.T) {
+	api := &mockapi.API{
+		GetUserFunc: func(ctx context.Context, userID string) (*thetalake.User, error) {
+			return nil, &thetalake.APIError{StatusCode: http.StatusNotFound}
+		},
+	}
+
+	resp := testRead(t, &UserResource{client: api}, &UserResourceModel{
+		ID: types.StringValue("42"),
+	})
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected error: %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("the user should be removed from state when it is deleted")
+	}
+}
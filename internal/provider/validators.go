@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/helpers/validatordiag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Enumerated values accepted by the API.
+var (
+	caseVisibilities = []string{"PUBLIC", "PRIVATE"}
+	caseStatuses     = []string{"OPEN", "CLOSED"}
+	exportFormats    = []string{"csv", "json"}
+	reviewStates     = []string{"reviewed", "unreviewed", "compliant", "non-compliant"}
+)
+
+// isDate validates that a string is a calendar date in YYYY-MM-DD form.
+func isDate() validator.String {
+	return dateValidator{}
+}
+
+type dateValidator struct{}
+
+func (v dateValidator) Description(ctx context.Context) string {
+	return "value must be a date in YYYY-MM-DD format"
+}
+
+func (v dateValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a date in `YYYY-MM-DD` format"
+}
+
+func (v dateValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.DateOnly, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path, v.Description(ctx), fmt.Sprintf("%q", req.ConfigValue.ValueString()),
+		))
+	}
+}
+
+// isEmail validates that a string is a bare email address, without a display
+// name or angle brackets.
+func isEmail() validator.String {
+	return emailValidator{}
+}
+
+type emailValidator struct{}
+
+func (v emailValidator) Description(ctx context.Context) string {
+	return "value must be an email address"
+}
+
+func (v emailValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v emailValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if addr, err := mail.ParseAddress(value); err != nil || addr.Address != value {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeValueDiagnostic(
+			req.Path, v.Description(ctx), fmt.Sprintf("%q", value),
+		))
+	}
+}
+
+// isRegexp validates that a string is a regular expression in Go (RE2) syntax.
+func isRegexp() validator.String {
+	return regexpValidator{}
+}
+
+type regexpValidator struct{}
+
+func (v regexpValidator) Description(ctx context.Context) string {
+	return "value must be a valid regular expression"
+}
+
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexpValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Attribute Value", fmt.Sprintf("%s: %s", v.Description(ctx), err))
+	}
+}
+
+// stringsEqual validates that two string attributes are configured with the
+// same value, such as a password and its confirmation. The check is skipped
+// while either value is unknown.
+func stringsEqual(attr, other path.Path) resource.ConfigValidator {
+	return stringsEqualValidator{attr: attr, other: other}
+}
+
+type stringsEqualValidator struct {
+	attr, other path.Path
+}
+
+func (v stringsEqualValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("%s must equal %s", v.other, v.attr)
+}
+
+func (v stringsEqualValidator) MarkdownDescription(ctx context.Context) string {
+	return fmt.Sprintf("`%s` must equal `%s`", v.other, v.attr)
+}
+
+func (v stringsEqualValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var a, b types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.attr, &a)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, v.other, &b)...)
+	if resp.Diagnostics.HasError() || a.IsUnknown() || b.IsUnknown() {
+		return
+	}
+
+	if !a.Equal(b) {
+		resp.Diagnostics.Append(validatordiag.InvalidAttributeCombinationDiagnostic(v.other, v.Description(ctx)))
+	}
+}
@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringValidators(t *testing.T) {
+	tests := []struct {
+		name      string
+		validator validator.String
+		value     types.String
+		wantError bool
+	}{
+		{"date", isDate(), types.StringValue("2024-02-29"), false},
+		{"date with time", isDate(), types.StringValue("2024-02-29T10:00:00Z"), true},
+		{"date out of range", isDate(), types.StringValue("2023-02-29"), true},
+		{"date day first", isDate(), types.StringValue("29-02-2024"), true},
+		{"date null", isDate(), types.StringNull(), false},
+		{"date unknown", isDate(), types.StringUnknown(), false},
+		{"email", isEmail(), types.StringValue("jane.doe@example.com"), false},
+		{"email without domain", isEmail(), types.StringValue("jane.doe"), true},
+		{"email with display name", isEmail(), types.StringValue("Jane <jane.doe@example.com>"), true},
+		{"email null", isEmail(), types.StringNull(), false},
+		{"regexp", isRegexp(), types.StringValue(`(?i)^[a-z.]+@example\.com$`), false},
+		{"regexp unbalanced", isRegexp(), types.StringValue(`(reviewer`), true},
+		{"regexp lookahead", isRegexp(), types.StringValue(`^(?!admin)`), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := validator.StringRequest{Path: path.Root("value"), ConfigValue: tt.value}
+			var resp validator.StringResponse
+			tt.validator.ValidateString(t.Context(), req, &resp)
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("got error %t, want %t: %v", got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestEnumeratedAttributes(t *testing.T) {
+	tests := []struct {
+		resource  resource.Resource
+		attribute string
+		value     string
+		wantError bool
+	}{
+		{&CaseResource{}, "visibility", "PRIVATE", false},
+		{&CaseResource{}, "visibility", "INTERNAL", true},
+		{&CaseResource{}, "status", "CLOSED", false},
+		{&CaseResource{}, "status", "ARCHIVED", true},
+		{&ExportResource{}, "format", "CSV", false},
+		{&ExportResource{}, "format", "xml", true},
+		{&RecordResource{}, "review_state", "non-compliant", false},
+		{&RecordResource{}, "review_state", "pending", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.attribute+" "+tt.value, func(t *testing.T) {
+			ctx := t.Context()
+
+			var schemaResp resource.SchemaResponse
+			tt.resource.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			attribute, ok := schemaResp.Schema.Attributes[tt.attribute].(schema.StringAttribute)
+			if !ok {
+				t.Fatalf("no string attribute %q", tt.attribute)
+			}
+
+			req := validator.StringRequest{Path: path.Root(tt.attribute), ConfigValue: types.StringValue(tt.value)}
+			var resp validator.StringResponse
+			for _, v := range attribute.Validators {
+				v.ValidateString(ctx, req, &resp)
+			}
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("got error %t, want %t: %v", got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
+
+func TestUserResourcePasswordConfirmation(t *testing.T) {
+	tests := []struct {
+		name         string
+		password     types.String
+		confirmation types.String
+		wantError    bool
+	}{
+		{"equal", types.StringValue("s3cret"), types.StringValue("s3cret"), false},
+		{"different", types.StringValue("s3cret"), types.StringValue("secret"), true},
+		{"unknown", types.StringValue("s3cret"), types.StringUnknown(), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := t.Context()
+			r := &UserResource{}
+
+			var schemaResp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+			// Config has no setter, so build the value through a state.
+			state := tfsdk.State{Schema: schemaResp.Schema}
+			diags := state.Set(ctx, &UserResourceModel{
+				Name:                 types.StringValue("jane"),
+				Email:                types.StringValue("jane.doe@example.com"),
+				Password:             tt.password,
+				PasswordConfirmation: tt.confirmation,
+			})
+			if diags.HasError() {
+				t.Fatalf("building config: %v", diags)
+			}
+
+			req := resource.ValidateConfigRequest{Config: tfsdk.Config{Schema: state.Schema, Raw: state.Raw}}
+			var resp resource.ValidateConfigResponse
+			for _, v := range r.ConfigValidators(ctx) {
+				v.ValidateResource(ctx, req, &resp)
+			}
+
+			if got := resp.Diagnostics.HasError(); got != tt.wantError {
+				t.Errorf("got error %t, want %t: %v", got, tt.wantError, resp.Diagnostics)
+			}
+		})
+	}
+}
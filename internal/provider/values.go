@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// stringOrNull maps the empty string the API returns for an unset optional
+// field to null, so an attribute left out of the configuration stays null in
+// state instead of producing a diff.
+func stringOrNull(v string) types.String {
+	if v == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(v)
+}
+
+// parseID converts an optional ID attribute to the integer the API expects,
+// returning zero when it is null.
+func parseID(p path.Path, v types.String, diags *diag.Diagnostics) int {
+	if v.IsNull() || v.IsUnknown() {
+		return 0
+	}
+
+	id, err := strconv.Atoi(v.ValueString())
+	if err != nil {
+		diags.AddAttributeError(p, "Invalid ID", fmt.Sprintf("Expected a numeric ID, got: %q", v.ValueString()))
+	}
+
+	return id
+}
+
+// idOrNull maps the zero ID the API returns for an unset reference to null.
+func idOrNull(id int) types.String {
+	if id == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(strconv.Itoa(id))
+}
@@ -0,0 +1,76 @@
+package thetalake
+
+import "context"
+
+// API is the set of Theta Lake operations the provider uses. *Client
+// implements it against the real API; tests substitute a mock.
+type API interface {
+	GetCase(ctx context.Context, caseID string) (*Case, error)
+	CreateCase(ctx context.Context, theCase Case) (*Case, error)
+	UpdateCase(ctx context.Context, caseID string, theCase Case) (*Case, error)
+	DeleteCase(ctx context.Context, caseID string) error
+	SearchCases(ctx context.Context, filter CaseFilter, opts ListOptions) ([]Case, error)
+	UpdateCaseStatus(ctx context.Context, caseID string, status string) error
+	AddRecordToCase(ctx context.Context, caseID string, recordID string) error
+	RemoveRecordFromCase(ctx context.Context, caseID string, recordID string) error
+	GetCaseRecords(ctx context.Context, caseID string, opts ListOptions) ([]Record, error)
+	GetCaseMember(ctx context.Context, caseID string, memberID string) (*CaseMember, error)
+	CreateCaseMember(ctx context.Context, caseID string, member CaseMember) (*CaseMember, error)
+	UpdateCaseMember(ctx context.Context, caseID string, memberID string, member CaseMember) (*CaseMember, error)
+	DeleteCaseMember(ctx context.Context, caseID string, memberID string) error
+
+	GetUser(ctx context.Context, userID string) (*User, error)
+	CreateUser(ctx context.Context, user User) (*User, error)
+	UpdateUser(ctx context.Context, userID string, user User) (*User, error)
+	DeleteUser(ctx context.Context, userID string) error
+	SearchUsers(ctx context.Context, filter UserFilter, opts ListOptions) ([]User, error)
+	SetUserPassword(ctx context.Context, userID string, password string, passwordConfirmation string) error
+	EnableUser(ctx context.Context, userID string) error
+	DisableUser(ctx context.Context, userID string) error
+
+	GetRole(ctx context.Context, roleID string) (*Role, error)
+	CreateRole(ctx context.Context, role Role) (*Role, error)
+	UpdateRole(ctx context.Context, roleID string, role Role) (*Role, error)
+	DeleteRole(ctx context.Context, roleID string) error
+	GetRoles(ctx context.Context, filter RoleFilter, opts ListOptions) ([]Role, error)
+
+	GetDirectoryGroup(ctx context.Context, groupID string) (*DirectoryGroup, error)
+	CreateDirectoryGroup(ctx context.Context, group DirectoryGroup) (*DirectoryGroup, error)
+	UpdateDirectoryGroup(ctx context.Context, groupID string, group DirectoryGroup) (*DirectoryGroup, error)
+	DeleteDirectoryGroup(ctx context.Context, groupID string) error
+
+	GetRetentionPolicy(ctx context.Context, policyID string) (*RetentionPolicy, error)
+	CreateRetentionPolicy(ctx context.Context, policy RetentionPolicy) (*RetentionPolicy, error)
+	UpdateRetentionPolicy(ctx context.Context, policyID string, policy RetentionPolicy) (*RetentionPolicy, error)
+	DeleteRetentionPolicy(ctx context.Context, policyID string) error
+
+	GetLegalHold(ctx context.Context, holdID string) (*LegalHold, error)
+	CreateLegalHold(ctx context.Context, hold LegalHold) (*LegalHold, error)
+	UpdateLegalHold(ctx context.Context, holdID string, hold LegalHold) (*LegalHold, error)
+	DeleteLegalHold(ctx context.Context, holdID string) error
+
+	GetTag(ctx context.Context, tagID string) (*Tag, error)
+	CreateTag(ctx context.Context, tag Tag) (*Tag, error)
+	UpdateTag(ctx context.Context, tagID string, tag Tag) (*Tag, error)
+	DeleteTag(ctx context.Context, tagID string) error
+
+	GetExport(ctx context.Context, exportID string) (*Export, error)
+	CreateExport(ctx context.Context, export Export) (*Export, error)
+	DeleteExport(ctx context.Context, exportID string) error
+
+	GetRecord(ctx context.Context, recordID string) (*Record, error)
+	UpdateRecordReviewState(ctx context.Context, recordID string, reviewState string, comment string) (*Record, error)
+
+	GetIntegrationState(ctx context.Context, integrationID string) (*IntegrationState, error)
+	UpdateIntegrationState(ctx context.Context, integrationID string, paused bool) (*IntegrationState, error)
+
+	GetAnalysis(ctx context.Context, analysisID string) (*Analysis, error)
+	GetAnalysisPolicies(ctx context.Context) ([]AnalysisPolicy, error)
+	GetAnalysisPolicyHits(ctx context.Context, opts ListOptions) ([]PolicyHit, error)
+
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter, opts ListOptions) ([]AuditLog, error)
+	GetEvents(ctx context.Context, opts ListOptions) ([]Event, error)
+	GetSystemStatus(ctx context.Context) (*SystemStatus, error)
+}
+
+var _ API = (*Client)(nil)
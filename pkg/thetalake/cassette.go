@@ -0,0 +1,313 @@
+package thetalake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteMode selects whether a Cassette talks to the API.
+type CassetteMode int
+
+const (
+	// CassetteRecord forwards requests to the API and records every exchange.
+	CassetteRecord CassetteMode = iota
+	// CassetteReplay answers requests from a recorded cassette without any
+	// network access.
+	CassetteReplay
+)
+
+// ParseCassetteMode parses "record" or "replay".
+func ParseCassetteMode(s string) (CassetteMode, error) {
+	switch strings.ToLower(s) {
+	case "record":
+		return CassetteRecord, nil
+	case "replay":
+		return CassetteReplay, nil
+	}
+
+	return 0, fmt.Errorf("unknown cassette mode %q, expected record or replay", s)
+}
+
+// Interaction is one recorded request and the response the API gave.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest identifies a request. Headers are not recorded, and secret
+// fields in the body are masked before it is written.
+type RecordedRequest struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	Text   string          `json:"text,omitempty"`
+}
+
+// RecordedResponse is what the API returned. Only the Content-Type header is
+// kept.
+type RecordedResponse struct {
+	StatusCode  int             `json:"status_code"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	Text        string          `json:"text,omitempty"`
+}
+
+// Cassette records API traffic to a JSON file or replays it from one. Its
+// Transport method returns the http.RoundTripper that does the work. Recorded
+// bodies are sanitized the same way as logged ones, so cassettes can be
+// committed.
+//
+// In replay mode a request matches an interaction with the same method, path,
+// query and body, where JSON bodies are compared after normalizing key order
+// and masking secrets. Matching interactions are served in recorded order; once
+// all are used, the last one is served again.
+type Cassette struct {
+	file string
+	mode CassetteMode
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+}
+
+type cassetteFile struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// NewCassette opens the cassette at file. In replay mode the file must exist;
+// in record mode it is written by Save.
+func NewCassette(file string, mode CassetteMode) (*Cassette, error) {
+	c := &Cassette{file: file, mode: mode}
+
+	if mode == CassetteReplay {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var f cassetteFile
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, fmt.Errorf("parsing cassette %s: %w", file, err)
+		}
+		// The file is indented; bring recorded bodies back to the compact form
+		// incoming requests are compared in.
+		for i, in := range f.Interactions {
+			if len(in.Request.Body) > 0 {
+				f.Interactions[i].Request.Body, _ = sanitizeBody(in.Request.Body, "")
+			}
+		}
+		c.interactions = f.Interactions
+		c.used = make([]bool, len(f.Interactions))
+	}
+
+	return c, nil
+}
+
+// Transport returns an http.RoundTripper backed by the cassette. When
+// recording, next performs the real requests; nil means http.DefaultTransport.
+// Several transports may share one cassette, e.g. one per configured client.
+func (c *Cassette) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &cassetteTransport{cassette: c, next: next}
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c := t.cassette
+
+	reqBody, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  redactQuery(req.URL.RawQuery),
+	}
+	recorded.Body, recorded.Text = sanitizeBody(reqBody, req.Header.Get("Content-Type"))
+
+	if c.mode == CassetteReplay {
+		return c.replay(req, recorded)
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resBody, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(resBody))
+
+	interaction := Interaction{
+		Request: recorded,
+		Response: RecordedResponse{
+			StatusCode:  res.StatusCode,
+			ContentType: res.Header.Get("Content-Type"),
+		},
+	}
+	interaction.Response.Body, interaction.Response.Text = sanitizeBody(resBody, res.Header.Get("Content-Type"))
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, interaction)
+	c.mu.Unlock()
+
+	return res, nil
+}
+
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, in := range c.interactions {
+		if !in.Request.matches(recorded) {
+			continue
+		}
+		if !c.used[i] {
+			c.used[i] = true
+			return in.Response.httpResponse(req), nil
+		}
+		last = i
+	}
+
+	if last >= 0 {
+		return c.interactions[last].Response.httpResponse(req), nil
+	}
+
+	return nil, fmt.Errorf("cassette %s has no interaction for %s %s", filepath.Base(c.file), req.Method, req.URL.RequestURI())
+}
+
+// Save writes the recorded interactions to the cassette file, creating its
+// directory. It does nothing in replay mode.
+func (c *Cassette) Save() error {
+	if c.mode != CassetteRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.file), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.file, append(data, '\n'), 0o644)
+}
+
+func (r RecordedRequest) matches(other RecordedRequest) bool {
+	return r.Method == other.Method &&
+		r.Path == other.Path &&
+		r.Query == other.Query &&
+		bytes.Equal(r.Body, other.Body) &&
+		r.Text == other.Text
+}
+
+func (r RecordedResponse) httpResponse(req *http.Request) *http.Response {
+	body := []byte(r.Text)
+	if len(r.Body) > 0 {
+		body = r.Body
+	}
+
+	header := http.Header{}
+	if r.ContentType != "" {
+		header.Set("Content-Type", r.ContentType)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		StatusCode:    r.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// readRequestBody returns the request body without consuming it.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+
+		return io.ReadAll(rc)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
+
+// sanitizeBody returns a JSON body normalized, with secret fields masked, or
+// any other body as text. Form bodies are masked as well.
+func sanitizeBody(body []byte, contentType string) (json.RawMessage, string) {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, ""
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err == nil {
+		if out, err := json.Marshal(redactJSON(doc)); err == nil {
+			return out, ""
+		}
+	}
+
+	// Not truncated: a cut body would replay as a different response.
+	return nil, redactFullBody(body, contentType)
+}
+
+// redactQuery masks sensitive query parameters and sorts the rest, so the
+// recorded query does not depend on parameter order.
+func redactQuery(raw string) string {
+	values, err := url.ParseQuery(raw)
+	if err != nil {
+		return raw
+	}
+	for k := range values {
+		if isSensitiveKey(k) {
+			values.Set(k, redacted)
+		}
+	}
+
+	return values.Encode()
+}
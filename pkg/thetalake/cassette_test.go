@@ -0,0 +1,219 @@
+package thetalake
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassetteRecordAndReplay(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cassettes", "case.json")
+
+	var cases int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "POST":
+			cases++
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"id": %d, "name": "case", "number": "CASE-1"}`, cases)
+		case "GET":
+			fmt.Fprintf(w, `{"id": %d, "name": "case", "number": "CASE-1", "token": "secret"}`, cases)
+		}
+	}))
+	defer server.Close()
+
+	recorder, err := NewCassette(file, CassetteRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := NewClient(server.URL+"/api/v1", "live-token")
+	c.HTTPClient = &http.Client{Transport: recorder.Transport(nil)}
+
+	created, err := c.CreateCase(t.Context(), Case{Name: "case", Number: "CASE-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCase(t.Context(), "1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, leak := range []string{"live-token", "secret", "127.0.0.1"} {
+		if bytes.Contains(data, []byte(leak)) {
+			t.Errorf("cassette leaks %q:\n%s", leak, data)
+		}
+	}
+
+	server.Close()
+
+	player, err := NewCassette(file, CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ = NewClient("https://replay.invalid/api/v1", "any-token")
+	c.MaxRetries = 0
+	c.HTTPClient = &http.Client{Transport: player.Transport(nil)}
+
+	replayed, err := c.CreateCase(t.Context(), Case{Name: "case", Number: "CASE-1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replayed.ID != created.ID {
+		t.Errorf("replayed case %+v, recorded %+v", replayed, created)
+	}
+	if _, err := c.GetCase(t.Context(), "1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetCase(t.Context(), "2"); err == nil || !strings.Contains(err.Error(), "no interaction") {
+		t.Errorf("expected an unmatched request to fail, got %v", err)
+	}
+}
+
+func TestCassetteReplaysPageTokens(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "hits.json")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("cursor") {
+		case "":
+			fmt.Fprint(w, `{"hits": [{"id": "a"}], "next_page_token": "page-2"}`)
+		case "page-2":
+			fmt.Fprint(w, `{"hits": [{"id": "b"}]}`)
+		default:
+			http.Error(w, "unknown cursor", http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	recorder, err := NewCassette(file, CassetteRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ := NewClient(server.URL+"/api/v1", "live-token")
+	c.HTTPClient = &http.Client{Transport: recorder.Transport(nil)}
+
+	if hits, err := c.GetAnalysisPolicyHits(t.Context(), ListOptions{}); err != nil || len(hits) != 2 {
+		t.Fatalf("recording: got %d hits, error %v", len(hits), err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	player, err := NewCassette(file, CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, _ = NewClient("https://replay.invalid/api/v1", "any-token")
+	c.MaxRetries = 0
+	c.HTTPClient = &http.Client{Transport: player.Transport(nil)}
+
+	// The second request is only found if the page token was recorded as is.
+	hits, err := c.GetAnalysisPolicyHits(t.Context(), ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hits) != 2 || hits[1].ID != "b" {
+		t.Errorf("unexpected hits %+v", hits)
+	}
+}
+
+func TestCassetteMatchesNormalizedBody(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "users.json")
+	cassette := cassetteFile{Interactions: []Interaction{{
+		Request: RecordedRequest{
+			Method: "POST",
+			Path:   "/users",
+			Body:   json.RawMessage(`{"email":"jane@example.com","name":"jane","password":"***"}`),
+		},
+		Response: RecordedResponse{StatusCode: 201, Body: json.RawMessage(`{"id":7}`)},
+	}}}
+	data, _ := json.Marshal(cassette)
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := NewCassette(file, CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Key order and the password value differ from the recording.
+	body := `{"password": "another", "name": "jane", "email": "jane@example.com"}`
+	req, _ := http.NewRequest("POST", "https://replay.invalid/users", strings.NewReader(body))
+	res, err := player.Transport(nil).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(res.Body)
+	if res.StatusCode != 201 || string(got) != `{"id":7}` {
+		t.Errorf("unexpected response %d %s", res.StatusCode, got)
+	}
+
+	req, _ = http.NewRequest("POST", "https://replay.invalid/users", strings.NewReader(`{"name": "john"}`))
+	if _, err := player.Transport(nil).RoundTrip(req); err == nil {
+		t.Error("expected a different body not to match")
+	}
+}
+
+func TestCassetteKeepsLargeTextBodies(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "content.json")
+	content := strings.Repeat("transcript line\n", 2*maxLoggedBodySize/16)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, content)
+	}))
+	defer server.Close()
+
+	recorder, err := NewCassette(file, CassetteRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest("GET", server.URL+"/records/1/content", nil)
+	res, err := recorder.Transport(nil).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	player, err := NewCassette(file, CassetteReplay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ = http.NewRequest("GET", "https://replay.invalid/records/1/content", nil)
+	res, err = player.Transport(nil).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, _ := io.ReadAll(res.Body)
+	if string(got) != content {
+		t.Errorf("replayed %d bytes, want the %d recorded", len(got), len(content))
+	}
+}
+
+func TestParseCassetteMode(t *testing.T) {
+	if mode, err := ParseCassetteMode("Replay"); err != nil || mode != CassetteReplay {
+		t.Errorf("unexpected mode %v, %v", mode, err)
+	}
+	if _, err := ParseCassetteMode("rewind"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
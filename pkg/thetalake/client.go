@@ -0,0 +1,2071 @@
+package thetalake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Case statuses. A case is opened and closed through UpdateCaseStatus; the
+// create and update calls ignore the status.
+const (
+	CaseStatusOpen   = "OPEN"
+	CaseStatusClosed = "CLOSED"
+)
+
+// Case represents a Theta Lake Case.
+type Case struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Number      string `json:"number"`
+	OpenDate    string `json:"open_date"`
+	Visibility  string `json:"visibility"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+	CloseDate   string `json:"close_date,omitempty"`
+}
+
+// CurrentStatus returns CaseStatusOpen or CaseStatusClosed. Responses that
+// omit the status are closed if they carry a close date.
+func (c *Case) CurrentStatus() string {
+	switch {
+	case c.Status != "":
+		return strings.ToUpper(c.Status)
+	case c.CloseDate != "":
+		return CaseStatusClosed
+	default:
+		return CaseStatusOpen
+	}
+}
+
+// User represents a Theta Lake User.
+type User struct {
+	ID                   int    `json:"id,omitempty"`
+	Name                 string `json:"name"`
+	Email                string `json:"email"`
+	Password             string `json:"password,omitempty"`
+	PasswordConfirmation string `json:"password_confirmation,omitempty"`
+	RoleID               int    `json:"role_id,omitempty"`
+	SearchID             int    `json:"search_id,omitempty"`
+	// Active is reported by the API and only changes through EnableUser and
+	// DisableUser.
+	Active *bool `json:"active,omitempty"`
+	// DirectoryGroupIDs is reported by the API. Group membership is synced
+	// from the identity provider and cannot be changed through the API.
+	DirectoryGroupIDs []int `json:"directory_group_ids,omitempty"`
+}
+
+// IsActive reports whether the user can sign in. Users are active unless the
+// API says otherwise.
+func (u *User) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// DirectoryGroup represents a Theta Lake Directory Group.
+type DirectoryGroup struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	ExternalID  string `json:"external_id,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Client holds the connection details for the Theta Lake API.
+type Client struct {
+	Endpoint   string
+	Token      string
+	HTTPClient *http.Client
+
+	// UserAgent is sent with every request. NewClient sets DefaultUserAgent.
+	UserAgent string
+
+	// TokenSource, when set, supplies the bearer token instead of Token. Sources
+	// that can refresh their token get one chance to do so after a 401.
+	TokenSource TokenSource
+
+	// MaxRetries is how many times an idempotent request is retried after a
+	// rate limit, a transient server error or a transport failure.
+	MaxRetries int
+	// RetryWaitMin and RetryWaitMax bound the exponential backoff between attempts.
+	RetryWaitMin time.Duration
+	RetryWaitMax time.Duration
+
+	limiter  *rateLimiter
+	inflight chan struct{}
+}
+
+// NewClient creates a new Theta Lake API client for the API at endpoint, such
+// as "https://api.thetalake.ai/api/v1", authenticating with token. Options are
+// applied in order on top of the defaults.
+func NewClient(endpoint, token string, opts ...Option) (*Client, error) {
+	if endpoint == "" {
+		return nil, errors.New("thetalake: endpoint is required")
+	}
+
+	c := &Client{
+		Endpoint: strings.TrimSuffix(endpoint, "/"),
+		Token:    token,
+		HTTPClient: &http.Client{
+			Timeout: DefaultRequestTimeout,
+		},
+		UserAgent:    DefaultUserAgent,
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+// DoRequest performs the HTTP request, retrying idempotent requests that fail
+// with a transient error.
+func (c *Client) DoRequest(req *http.Request) (*http.Response, error) {
+	req.Header.Set("Content-Type", "application/json")
+	if c.UserAgent != "" {
+		req.Header.Set("User-Agent", c.UserAgent)
+	}
+
+	replayable := req.Body == nil || req.GetBody != nil
+	retryable := isIdempotent(req.Method) && replayable
+	refreshedToken := false
+
+	for attempt, sent := 0, false; ; sent = true {
+		if sent && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		token, err := c.bearerToken(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+
+		release, err := c.acquire(req.Context())
+		if err != nil {
+			return nil, err
+		}
+
+		logRequest(req.Context(), req, attempt)
+		start := time.Now()
+		res, err := c.HTTPClient.Do(req)
+		logResponse(req.Context(), req, res, err, time.Since(start))
+		if err != nil {
+			release()
+		} else {
+			res.Body = &releaseOnClose{ReadCloser: res.Body, release: release}
+		}
+
+		// A rejected token is refreshed once and the request sent again. The API
+		// did not act on the request, so this is safe for every method.
+		if err == nil && res.StatusCode == http.StatusUnauthorized && replayable && !refreshedToken {
+			if inv, ok := c.TokenSource.(invalidator); ok {
+				refreshedToken = true
+				inv.Invalidate(token)
+				drainBody(res)
+				continue
+			}
+		}
+
+		if !retryable || attempt >= c.MaxRetries || req.Context().Err() != nil || !shouldRetry(res, err) {
+			return res, err
+		}
+
+		wait := c.backoff(attempt, res)
+		drainBody(res)
+		attempt++
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// bearerToken returns the token for the next request, preferring TokenSource
+// over the static Token.
+func (c *Client) bearerToken(ctx context.Context) (string, error) {
+	if c.TokenSource != nil {
+		return c.TokenSource.Token(ctx)
+	}
+
+	return c.Token, nil
+}
+
+// GetCase retrieves a case by ID.
+func (c *Client) GetCase(ctx context.Context, caseID string) (*Case, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/cases/%s", c.Endpoint, caseID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var theCase Case
+	err = json.Unmarshal(body, &theCase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &theCase, nil
+}
+
+// CreateCase creates a new case.
+func (c *Client) CreateCase(ctx context.Context, theCase Case) (*Case, error) {
+	rb, err := json.Marshal(theCase)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/cases", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newCase Case
+	err = json.Unmarshal(body, &newCase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newCase, nil
+}
+
+// UpdateCase updates an existing case.
+func (c *Client) UpdateCase(ctx context.Context, caseID string, theCase Case) (*Case, error) {
+	rb, err := json.Marshal(theCase)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/cases/%s", c.Endpoint, caseID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedCase Case
+	err = json.Unmarshal(body, &updatedCase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedCase, nil
+}
+
+// DeleteCase deletes a case.
+func (c *Client) DeleteCase(ctx context.Context, caseID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/cases/%s", c.Endpoint, caseID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// GetUser retrieves a user by ID.
+func (c *Client) GetUser(ctx context.Context, userID string) (*User, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/users/%s", c.Endpoint, userID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var user User
+	err = json.Unmarshal(body, &user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// CreateUser creates a new user.
+func (c *Client) CreateUser(ctx context.Context, user User) (*User, error) {
+	rb, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/users", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newUser User
+	err = json.Unmarshal(body, &newUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newUser, nil
+}
+
+// UpdateUser updates an existing user.
+func (c *Client) UpdateUser(ctx context.Context, userID string, user User) (*User, error) {
+	rb, err := json.Marshal(user)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/users/%s", c.Endpoint, userID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedUser User
+	err = json.Unmarshal(body, &updatedUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedUser, nil
+}
+
+// DeleteUser deletes a user.
+func (c *Client) DeleteUser(ctx context.Context, userID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/users/%s", c.Endpoint, userID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// SetUserPassword changes a user's password without otherwise modifying the
+// user. The confirmation must equal the password.
+func (c *Client) SetUserPassword(ctx context.Context, userID string, password string, passwordConfirmation string) error {
+	rb, err := json.Marshal(User{Password: password, PasswordConfirmation: passwordConfirmation})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/users/%s/password", c.Endpoint, userID), bytes.NewBuffer(rb))
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// UserFilter narrows down a user search. Empty fields match every user.
+type UserFilter struct {
+	Email            string
+	EmailDomain      string
+	RoleID           int
+	DirectoryGroupID int
+	Active           *bool
+
+	// EmailPattern is not understood by the API, so it is only applied to
+	// the returned pages.
+	EmailPattern *regexp.Regexp
+}
+
+// query returns the filter as query parameters for the user search endpoint.
+func (f UserFilter) query() url.Values {
+	q := url.Values{}
+	if f.Email != "" {
+		q.Set("email", f.Email)
+	}
+	if f.EmailDomain != "" {
+		q.Set("email_domain", strings.TrimPrefix(f.EmailDomain, "@"))
+	}
+	if f.RoleID != 0 {
+		q.Set("role_id", strconv.Itoa(f.RoleID))
+	}
+	if f.DirectoryGroupID != 0 {
+		q.Set("directory_group_id", strconv.Itoa(f.DirectoryGroupID))
+	}
+	if f.Active != nil {
+		q.Set("active", strconv.FormatBool(*f.Active))
+	}
+
+	return q
+}
+
+// Match reports whether a user satisfies the filter.
+func (f UserFilter) Match(u User) bool {
+	if f.Email != "" && !strings.EqualFold(u.Email, f.Email) {
+		return false
+	}
+	if f.EmailDomain != "" {
+		_, domain, _ := strings.Cut(u.Email, "@")
+		if !strings.EqualFold(domain, strings.TrimPrefix(f.EmailDomain, "@")) {
+			return false
+		}
+	}
+	if f.EmailPattern != nil && !f.EmailPattern.MatchString(u.Email) {
+		return false
+	}
+	if f.RoleID != 0 && u.RoleID != f.RoleID {
+		return false
+	}
+	if f.DirectoryGroupID != 0 && !slices.Contains(u.DirectoryGroupIDs, f.DirectoryGroupID) {
+		return false
+	}
+	if f.Active != nil && u.IsActive() != *f.Active {
+		return false
+	}
+
+	return true
+}
+
+// ListUsers returns an iterator over the users matching filter.
+func (c *Client) ListUsers(filter UserFilter, opts ListOptions) *PageIterator[User] {
+	it := newPageIterator[User](c, "/users", filter.query(), "users", opts.PageSize)
+	it.filter = filter.Match
+
+	return it
+}
+
+// SearchUsers retrieves the users matching filter, following pages up to opts.MaxResults.
+func (c *Client) SearchUsers(ctx context.Context, filter UserFilter, opts ListOptions) ([]User, error) {
+	return c.ListUsers(filter, opts).All(ctx, opts.MaxResults)
+}
+
+// EnableUser reactivates a disabled user.
+func (c *Client) EnableUser(ctx context.Context, userID string) error {
+	return c.setUserActive(ctx, userID, "enable")
+}
+
+// DisableUser deactivates a user without deleting it, so the user can no
+// longer sign in but stays attributable in audit logs and reviews.
+func (c *Client) DisableUser(ctx context.Context, userID string) error {
+	return c.setUserActive(ctx, userID, "disable")
+}
+
+// setUserActive calls PUT /users/{id}/enable or PUT /users/{id}/disable.
+func (c *Client) setUserActive(ctx context.Context, userID string, action string) error {
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/users/%s/%s", c.Endpoint, userID, action), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// Role is a named set of permissions that users are assigned through their
+// role ID. Built-in roles are provided by Theta Lake and cannot be changed.
+type Role struct {
+	ID          int      `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+	BuiltIn     bool     `json:"built_in,omitempty"`
+}
+
+// RoleFilter narrows down a role listing. Empty fields match every role.
+type RoleFilter struct {
+	Name string
+}
+
+// query returns the filter as query parameters for the role endpoint.
+func (f RoleFilter) query() url.Values {
+	q := url.Values{}
+	if f.Name != "" {
+		q.Set("name", f.Name)
+	}
+
+	return q
+}
+
+// Match reports whether a role satisfies the filter.
+func (f RoleFilter) Match(role Role) bool {
+	return f.Name == "" || strings.EqualFold(role.Name, f.Name)
+}
+
+// ListRoles returns an iterator over the roles matching filter.
+func (c *Client) ListRoles(filter RoleFilter, opts ListOptions) *PageIterator[Role] {
+	it := newPageIterator[Role](c, "/roles", filter.query(), "roles", opts.PageSize)
+	it.filter = filter.Match
+
+	return it
+}
+
+// GetRoles retrieves the roles matching filter, following pages up to opts.MaxResults.
+func (c *Client) GetRoles(ctx context.Context, filter RoleFilter, opts ListOptions) ([]Role, error) {
+	return c.ListRoles(filter, opts).All(ctx, opts.MaxResults)
+}
+
+// GetRole retrieves a role by ID.
+func (c *Client) GetRole(ctx context.Context, roleID string) (*Role, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/roles/%s", c.Endpoint, roleID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var role Role
+	err = json.Unmarshal(body, &role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+// CreateRole creates a custom role.
+func (c *Client) CreateRole(ctx context.Context, role Role) (*Role, error) {
+	rb, err := json.Marshal(role)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/roles", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newRole Role
+	err = json.Unmarshal(body, &newRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newRole, nil
+}
+
+// UpdateRole updates a custom role.
+func (c *Client) UpdateRole(ctx context.Context, roleID string, role Role) (*Role, error) {
+	rb, err := json.Marshal(role)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/roles/%s", c.Endpoint, roleID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedRole Role
+	err = json.Unmarshal(body, &updatedRole)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedRole, nil
+}
+
+// DeleteRole deletes a custom role.
+func (c *Client) DeleteRole(ctx context.Context, roleID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/roles/%s", c.Endpoint, roleID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// GetDirectoryGroup retrieves a directory group by ID.
+func (c *Client) GetDirectoryGroup(ctx context.Context, groupID string) (*DirectoryGroup, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/directory_groups/%s", c.Endpoint, groupID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var group DirectoryGroup
+	err = json.Unmarshal(body, &group)
+	if err != nil {
+		return nil, err
+	}
+
+	return &group, nil
+}
+
+// CreateDirectoryGroup creates a new directory group.
+func (c *Client) CreateDirectoryGroup(ctx context.Context, group DirectoryGroup) (*DirectoryGroup, error) {
+	rb, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/directory_groups", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newGroup DirectoryGroup
+	err = json.Unmarshal(body, &newGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newGroup, nil
+}
+
+// UpdateDirectoryGroup updates an existing directory group.
+func (c *Client) UpdateDirectoryGroup(ctx context.Context, groupID string, group DirectoryGroup) (*DirectoryGroup, error) {
+	rb, err := json.Marshal(group)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/directory_groups/%s", c.Endpoint, groupID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedGroup DirectoryGroup
+	err = json.Unmarshal(body, &updatedGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedGroup, nil
+}
+
+// DeleteDirectoryGroup deletes a directory group.
+func (c *Client) DeleteDirectoryGroup(ctx context.Context, groupID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/directory_groups/%s", c.Endpoint, groupID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// RetentionPolicy represents a Theta Lake Retention Policy.
+type RetentionPolicy struct {
+	ID                  int    `json:"id,omitempty"`
+	Name                string `json:"name"`
+	Description         string `json:"description,omitempty"`
+	RetentionPeriodDays int    `json:"retention_period_days,omitempty"`
+}
+
+// GetRetentionPolicy retrieves a retention policy by ID.
+func (c *Client) GetRetentionPolicy(ctx context.Context, policyID string) (*RetentionPolicy, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/retention_policies/%s", c.Endpoint, policyID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy RetentionPolicy
+	err = json.Unmarshal(body, &policy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// CreateRetentionPolicy creates a new retention policy.
+func (c *Client) CreateRetentionPolicy(ctx context.Context, policy RetentionPolicy) (*RetentionPolicy, error) {
+	rb, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/retention_policies", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newPolicy RetentionPolicy
+	err = json.Unmarshal(body, &newPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newPolicy, nil
+}
+
+// UpdateRetentionPolicy updates an existing retention policy.
+func (c *Client) UpdateRetentionPolicy(ctx context.Context, policyID string, policy RetentionPolicy) (*RetentionPolicy, error) {
+	rb, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/retention_policies/%s", c.Endpoint, policyID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedPolicy RetentionPolicy
+	err = json.Unmarshal(body, &updatedPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedPolicy, nil
+}
+
+// DeleteRetentionPolicy deletes a retention policy.
+func (c *Client) DeleteRetentionPolicy(ctx context.Context, policyID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/retention_policies/%s", c.Endpoint, policyID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// LegalHold represents a Theta Lake Legal Hold.
+type LegalHold struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	CaseID      int    `json:"case_id,omitempty"`
+}
+
+// GetLegalHold retrieves a legal hold by ID.
+func (c *Client) GetLegalHold(ctx context.Context, holdID string) (*LegalHold, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/legal_holds/%s", c.Endpoint, holdID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var hold LegalHold
+	err = json.Unmarshal(body, &hold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &hold, nil
+}
+
+// CreateLegalHold creates a new legal hold.
+func (c *Client) CreateLegalHold(ctx context.Context, hold LegalHold) (*LegalHold, error) {
+	rb, err := json.Marshal(hold)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/legal_holds", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newHold LegalHold
+	err = json.Unmarshal(body, &newHold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newHold, nil
+}
+
+// UpdateLegalHold updates an existing legal hold.
+func (c *Client) UpdateLegalHold(ctx context.Context, holdID string, hold LegalHold) (*LegalHold, error) {
+	rb, err := json.Marshal(hold)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/legal_holds/%s", c.Endpoint, holdID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedHold LegalHold
+	err = json.Unmarshal(body, &updatedHold)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedHold, nil
+}
+
+// DeleteLegalHold deletes a legal hold.
+func (c *Client) DeleteLegalHold(ctx context.Context, holdID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/legal_holds/%s", c.Endpoint, holdID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// Tag represents a Theta Lake Tag.
+type Tag struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// GetTag retrieves a tag by ID.
+func (c *Client) GetTag(ctx context.Context, tagID string) (*Tag, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/tags/%s", c.Endpoint, tagID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var tag Tag
+	err = json.Unmarshal(body, &tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tag, nil
+}
+
+// CreateTag creates a new tag.
+func (c *Client) CreateTag(ctx context.Context, tag Tag) (*Tag, error) {
+	rb, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/tags", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newTag Tag
+	err = json.Unmarshal(body, &newTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newTag, nil
+}
+
+// UpdateTag updates an existing tag.
+func (c *Client) UpdateTag(ctx context.Context, tagID string, tag Tag) (*Tag, error) {
+	rb, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/tags/%s", c.Endpoint, tagID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTag Tag
+	err = json.Unmarshal(body, &updatedTag)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedTag, nil
+}
+
+// DeleteTag deletes a tag.
+func (c *Client) DeleteTag(ctx context.Context, tagID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/tags/%s", c.Endpoint, tagID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// AuditLog represents a Theta Lake Audit Log entry.
+type AuditLog struct {
+	ID        string `json:"id"`
+	User      string `json:"user"`
+	Action    string `json:"action"`
+	Resource  string `json:"resource"`
+	Timestamp string `json:"timestamp"`
+}
+
+// AuditLogFilter narrows down an audit log listing. Empty fields match every
+// entry.
+type AuditLogFilter struct {
+	User     string
+	Action   string
+	Resource string
+	Since    time.Time
+	Until    time.Time
+}
+
+// query returns the filter as query parameters for the audit log endpoint.
+func (f AuditLogFilter) query() url.Values {
+	q := url.Values{}
+	if f.User != "" {
+		q.Set("user", f.User)
+	}
+	if f.Action != "" {
+		q.Set("action", f.Action)
+	}
+	if f.Resource != "" {
+		q.Set("resource", f.Resource)
+	}
+	if !f.Since.IsZero() {
+		q.Set("since", f.Since.UTC().Format(time.RFC3339))
+	}
+	if !f.Until.IsZero() {
+		q.Set("until", f.Until.UTC().Format(time.RFC3339))
+	}
+
+	return q
+}
+
+// Match reports whether an entry satisfies the filter. Entries whose timestamp
+// cannot be parsed are kept rather than hidden.
+func (f AuditLogFilter) Match(log AuditLog) bool {
+	if f.User != "" && !strings.EqualFold(log.User, f.User) {
+		return false
+	}
+	if f.Action != "" && !strings.EqualFold(log.Action, f.Action) {
+		return false
+	}
+	if f.Resource != "" && !strings.EqualFold(log.Resource, f.Resource) {
+		return false
+	}
+
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+
+	ts, err := time.Parse(time.RFC3339, log.Timestamp)
+	if err != nil {
+		return true
+	}
+	if !f.Since.IsZero() && ts.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && ts.After(f.Until) {
+		return false
+	}
+
+	return true
+}
+
+// ListAuditLogs returns an iterator over the audit log pages matching filter.
+func (c *Client) ListAuditLogs(filter AuditLogFilter, opts ListOptions) *PageIterator[AuditLog] {
+	it := newPageIterator[AuditLog](c, "/audit_logs", filter.query(), "audit_logs", opts.PageSize)
+	it.filter = filter.Match
+
+	return it
+}
+
+// GetAuditLogs retrieves audit logs matching filter, following pages up to opts.MaxResults.
+func (c *Client) GetAuditLogs(ctx context.Context, filter AuditLogFilter, opts ListOptions) ([]AuditLog, error) {
+	return c.ListAuditLogs(filter, opts).All(ctx, opts.MaxResults)
+}
+
+// Event represents a Theta Lake Event.
+type Event struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Content   string `json:"content"`
+	Timestamp string `json:"timestamp"`
+}
+
+// ListEvents returns an iterator over the event pages.
+func (c *Client) ListEvents(opts ListOptions) *PageIterator[Event] {
+	return newPageIterator[Event](c, "/events", nil, "events", opts.PageSize)
+}
+
+// GetEvents retrieves events, following pages up to opts.MaxResults.
+func (c *Client) GetEvents(ctx context.Context, opts ListOptions) ([]Event, error) {
+	return c.ListEvents(opts).All(ctx, opts.MaxResults)
+}
+
+// AnalysisPolicy represents a Theta Lake Analysis Policy.
+type AnalysisPolicy struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	IsBuiltIn   bool   `json:"is_built_in"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type analysisPoliciesResponse struct {
+	Policies []AnalysisPolicy `json:"policies"`
+}
+
+// GetAnalysisPolicies retrieves analysis policies.
+func (c *Client) GetAnalysisPolicies(ctx context.Context) ([]AnalysisPolicy, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/analysis/policies", c.Endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response analysisPoliciesResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Policies, nil
+}
+
+// IntegrationState represents the state of a Theta Lake Integration.
+type IntegrationState struct {
+	ID         int    `json:"id,omitempty"` // Not in API response, but useful for resource
+	Paused     bool   `json:"paused"`
+	LastRun    string `json:"last_run,omitempty"`
+	LastUpload string `json:"last_upload,omitempty"`
+}
+
+type integrationStateResponse struct {
+	State IntegrationState `json:"state"`
+}
+
+type integrationStateRequest struct {
+	Status string `json:"status"`
+}
+
+// GetIntegrationState retrieves the state of an integration.
+func (c *Client) GetIntegrationState(ctx context.Context, integrationID string) (*IntegrationState, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/ingestion/integration/%s/state", c.Endpoint, integrationID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response integrationStateResponse
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.State, nil
+}
+
+// UpdateIntegrationState updates the state of an integration (pauses/unpauses).
+func (c *Client) UpdateIntegrationState(ctx context.Context, integrationID string, paused bool) (*IntegrationState, error) {
+	status := "active"
+	if paused {
+		status = "paused"
+	}
+
+	reqBody := integrationStateRequest{
+		Status: status,
+	}
+
+	rb, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/ingestion/integration/%s/state", c.Endpoint, integrationID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	// The PUT response might not return the full state, so we fetch it again to be sure,
+	// or we can just return what we sent if the API doesn't return the state.
+	// Assuming API returns the updated state or similar structure.
+	// Research showed PUT response body wasn't explicitly captured in detail, but usually returns state.
+	// Let's try to parse it as state, if fails, fetch it.
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var response integrationStateResponse
+	if err := json.Unmarshal(body, &response); err == nil && response.State.LastRun != "" {
+		return &response.State, nil
+	}
+
+	// Fallback to GET
+	return c.GetIntegrationState(ctx, integrationID)
+}
+
+// Export represents a Theta Lake Export.
+type Export struct {
+	ID          int    `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	QueryID     int    `json:"query_id,omitempty"`
+	Format      string `json:"format,omitempty"`
+	Status      string `json:"status,omitempty"`
+	DownloadURL string `json:"download_url,omitempty"`
+}
+
+// GetExport retrieves an export by ID.
+func (c *Client) GetExport(ctx context.Context, exportID string) (*Export, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/exports/%s", c.Endpoint, exportID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var export Export
+	err = json.Unmarshal(body, &export)
+	if err != nil {
+		return nil, err
+	}
+
+	return &export, nil
+}
+
+// CreateExport creates a new export.
+func (c *Client) CreateExport(ctx context.Context, export Export) (*Export, error) {
+	rb, err := json.Marshal(export)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/exports", c.Endpoint), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newExport Export
+	err = json.Unmarshal(body, &newExport)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newExport, nil
+}
+
+// DeleteExport deletes an export.
+func (c *Client) DeleteExport(ctx context.Context, exportID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/exports/%s", c.Endpoint, exportID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// Record represents a Theta Lake Record.
+type Record struct {
+	ID           string   `json:"id"`
+	ContentDate  string   `json:"content_date"`
+	Participants []string `json:"participants"`
+	ReviewState  string   `json:"review_state,omitempty"`
+	Comment      string   `json:"comment,omitempty"`
+}
+
+type recordReviewStateRequest struct {
+	ReviewState string `json:"review_state"`
+	Comment     string `json:"comment,omitempty"`
+}
+
+// GetRecord retrieves a record by ID.
+func (c *Client) GetRecord(ctx context.Context, recordID string) (*Record, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/records/%s", c.Endpoint, recordID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var record Record
+	err = json.Unmarshal(body, &record)
+	if err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// UpdateRecordReviewState updates the review state of a record.
+func (c *Client) UpdateRecordReviewState(ctx context.Context, recordID string, reviewState string, comment string) (*Record, error) {
+	reqBody := recordReviewStateRequest{
+		ReviewState: reviewState,
+		Comment:     comment,
+	}
+
+	rb, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/records/%s/review_state", c.Endpoint, recordID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	// Assuming the API returns the updated record or we fetch it.
+	// Let's fetch it to be sure.
+	return c.GetRecord(ctx, recordID)
+}
+
+// SystemStatus represents the Theta Lake System Status.
+type SystemStatus struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+	Message string `json:"message,omitempty"`
+}
+
+// GetSystemStatus retrieves the system status.
+func (c *Client) GetSystemStatus(ctx context.Context) (*SystemStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/system/status", c.Endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var status SystemStatus
+	err = json.Unmarshal(body, &status)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// PolicyHit represents a Theta Lake Analysis Policy Hit.
+type PolicyHit struct {
+	ID         string `json:"id"`
+	PolicyID   int    `json:"policy_id"`
+	RecordID   string `json:"record_id"`
+	HitDate    string `json:"hit_date"`
+	Confidence int    `json:"confidence"`
+}
+
+// ListAnalysisPolicyHits returns an iterator over the analysis policy hit pages.
+func (c *Client) ListAnalysisPolicyHits(opts ListOptions) *PageIterator[PolicyHit] {
+	return newPageIterator[PolicyHit](c, "/analysis/policy_hits", nil, "hits", opts.PageSize)
+}
+
+// GetAnalysisPolicyHits retrieves analysis policy hits, following pages up to opts.MaxResults.
+func (c *Client) GetAnalysisPolicyHits(ctx context.Context, opts ListOptions) ([]PolicyHit, error) {
+	return c.ListAnalysisPolicyHits(opts).All(ctx, opts.MaxResults)
+}
+
+// AddRecordToCase adds a record to a case.
+func (c *Client) AddRecordToCase(ctx context.Context, caseID string, recordID string) error {
+	// Assuming the API expects a JSON body with record_id or similar.
+	// Based on typical patterns: POST /cases/{id}/records with body {"record_id": "..."}
+	reqBody := map[string]string{
+		"record_id": recordID,
+	}
+
+	rb, err := json.Marshal(reqBody)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/cases/%s/records", c.Endpoint, caseID), bytes.NewBuffer(rb))
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// RemoveRecordFromCase removes a record from a case.
+func (c *Client) RemoveRecordFromCase(ctx context.Context, caseID string, recordID string) error {
+	// The API takes the record as a query parameter: DELETE /cases/{id}/records?record_id=...
+	query := url.Values{"record_id": {recordID}}
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/cases/%s/records?%s", c.Endpoint, caseID, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// ListCaseRecords returns an iterator over the records linked to a case.
+func (c *Client) ListCaseRecords(caseID string, opts ListOptions) *PageIterator[Record] {
+	return newPageIterator[Record](c, fmt.Sprintf("/cases/%s/records", url.PathEscape(caseID)), nil, "records", opts.PageSize)
+}
+
+// GetCaseRecords returns the records linked to a case, following pagination
+// up to opts.MaxResults.
+func (c *Client) GetCaseRecords(ctx context.Context, caseID string, opts ListOptions) ([]Record, error) {
+	return c.ListCaseRecords(caseID, opts).All(ctx, opts.MaxResults)
+}
+
+// UpdateCaseStatus updates the status of a case (open/close).
+func (c *Client) UpdateCaseStatus(ctx context.Context, caseID string, status string) error {
+	// Status should be "OPEN" or "CLOSED" (or lowercase)
+	// API endpoints: PUT /cases/{id}/open, PUT /cases/{id}/close
+
+	var action string
+	switch strings.ToUpper(status) {
+	case CaseStatusOpen:
+		action = "open"
+	case CaseStatusClosed:
+		action = "close"
+	default:
+		return fmt.Errorf("invalid status: %s", status)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/cases/%s/%s", c.Endpoint, caseID, action), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// CaseFilter narrows down a case search. Empty fields match every case.
+type CaseFilter struct {
+	Number string
+	Name   string
+	Status string
+}
+
+// query returns the filter as query parameters for the case search endpoint.
+func (f CaseFilter) query() url.Values {
+	q := url.Values{}
+	if f.Number != "" {
+		q.Set("number", f.Number)
+	}
+	if f.Name != "" {
+		q.Set("name", f.Name)
+	}
+	if f.Status != "" {
+		q.Set("status", strings.ToUpper(f.Status))
+	}
+
+	return q
+}
+
+// Match reports whether a case satisfies the filter.
+func (f CaseFilter) Match(c Case) bool {
+	if f.Number != "" && !strings.EqualFold(c.Number, f.Number) {
+		return false
+	}
+	if f.Name != "" && !strings.EqualFold(c.Name, f.Name) {
+		return false
+	}
+	if f.Status != "" && !strings.EqualFold(c.CurrentStatus(), f.Status) {
+		return false
+	}
+
+	return true
+}
+
+// ListCases returns an iterator over the cases matching filter.
+func (c *Client) ListCases(filter CaseFilter, opts ListOptions) *PageIterator[Case] {
+	it := newPageIterator[Case](c, "/cases", filter.query(), "cases", opts.PageSize)
+	it.filter = filter.Match
+
+	return it
+}
+
+// SearchCases retrieves the cases matching filter, following pages up to opts.MaxResults.
+func (c *Client) SearchCases(ctx context.Context, filter CaseFilter, opts ListOptions) ([]Case, error) {
+	return c.ListCases(filter, opts).All(ctx, opts.MaxResults)
+}
+
+// CaseMember grants a user or a directory group a role on a case. Exactly one
+// of UserID and DirectoryGroupID is set.
+type CaseMember struct {
+	ID               int    `json:"id,omitempty"`
+	CaseID           int    `json:"case_id,omitempty"`
+	UserID           int    `json:"user_id,omitempty"`
+	DirectoryGroupID int    `json:"directory_group_id,omitempty"`
+	Role             string `json:"role"`
+}
+
+// GetCaseMember retrieves a member of a case by ID.
+func (c *Client) GetCaseMember(ctx context.Context, caseID string, memberID string) (*CaseMember, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/cases/%s/members/%s", c.Endpoint, caseID, memberID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var member CaseMember
+	err = json.Unmarshal(body, &member)
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// CreateCaseMember grants a user or a directory group access to a case.
+func (c *Client) CreateCaseMember(ctx context.Context, caseID string, member CaseMember) (*CaseMember, error) {
+	rb, err := json.Marshal(member)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s/cases/%s/members", c.Endpoint, caseID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusCreated && res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var newMember CaseMember
+	err = json.Unmarshal(body, &newMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &newMember, nil
+}
+
+// UpdateCaseMember changes the role of a member of a case.
+func (c *Client) UpdateCaseMember(ctx context.Context, caseID string, memberID string, member CaseMember) (*CaseMember, error) {
+	rb, err := json.Marshal(member)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "PUT", fmt.Sprintf("%s/cases/%s/members/%s", c.Endpoint, caseID, memberID), bytes.NewBuffer(rb))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedMember CaseMember
+	err = json.Unmarshal(body, &updatedMember)
+	if err != nil {
+		return nil, err
+	}
+
+	return &updatedMember, nil
+}
+
+// DeleteCaseMember revokes a member's access to a case.
+func (c *Client) DeleteCaseMember(ctx context.Context, caseID string, memberID string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s/cases/%s/members/%s", c.Endpoint, caseID, memberID), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNoContent {
+		return newAPIError(res)
+	}
+
+	return nil
+}
+
+// Analysis represents a Theta Lake Analysis result.
+type Analysis struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+	Details   string `json:"details,omitempty"`
+}
+
+// GetAnalysis retrieves an analysis by ID.
+func (c *Client) GetAnalysis(ctx context.Context, analysisID string) (*Analysis, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/analysis/%s", c.Endpoint, analysisID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var analysis Analysis
+	err = json.Unmarshal(body, &analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	return &analysis, nil
+}
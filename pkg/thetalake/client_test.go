@@ -0,0 +1,117 @@
+package thetalake
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestGetAuditLogsFilter(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("action") != "role_change" || q.Get("since") != "2024-01-01T00:00:00Z" {
+			t.Errorf("filter not pushed down: %s", r.URL.RawQuery)
+		}
+
+		// Pretend the API only understands "action" and returns the rest unfiltered.
+		fmt.Fprint(w, `[
+			{"id": "1", "user": "admin@example.com", "action": "ROLE_CHANGE", "timestamp": "2023-12-31T23:59:59Z"},
+			{"id": "2", "user": "admin@example.com", "action": "role_change", "timestamp": "2024-01-01T10:00:00Z"},
+			{"id": "3", "user": "other@example.com", "action": "role_change", "timestamp": "2024-01-01T11:00:00Z"},
+			{"id": "4", "user": "admin@example.com", "action": "role_change", "timestamp": "not a time"}
+		]`)
+	})
+
+	filter := AuditLogFilter{
+		User:   "Admin@example.com",
+		Action: "role_change",
+		Since:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	logs, err := c.GetAuditLogs(t.Context(), filter, ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var ids []string
+	for _, log := range logs {
+		ids = append(ids, log.ID)
+	}
+	if fmt.Sprint(ids) != "[2 4]" {
+		t.Errorf("unexpected entries %v", ids)
+	}
+}
+
+func TestSearchCasesFilter(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("number"); got != "CASE-2023-001" {
+			t.Errorf("number not pushed down: %s", r.URL.RawQuery)
+		}
+
+		// Pretend the API ignores the filter.
+		fmt.Fprint(w, `{"cases": [
+			{"id": 1, "name": "first", "number": "CASE-2023-001"},
+			{"id": 2, "name": "second", "number": "CASE-2023-0012"},
+			{"id": 3, "name": "third", "number": "case-2023-001", "close_date": "2024-01-01"}
+		]}`)
+	})
+
+	cases, err := c.SearchCases(t.Context(), CaseFilter{Number: "CASE-2023-001", Status: "open"}, ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(cases) != 1 || cases[0].ID != 1 {
+		t.Errorf("unexpected cases %+v", cases)
+	}
+}
+
+func TestSearchUsersFilter(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("email"); got != "jane.doe@example.com" {
+			t.Errorf("email not pushed down: %s", r.URL.RawQuery)
+		}
+
+		// Pretend the API ignores the filter.
+		fmt.Fprint(w, `{"users": [
+			{"id": 1, "name": "jane", "email": "Jane.Doe@example.com"},
+			{"id": 2, "name": "john", "email": "john.doe@example.com"},
+			{"id": 3, "name": "jane", "email": "jane.doe@example.com.invalid"}
+		]}`)
+	})
+
+	users, err := c.SearchUsers(t.Context(), UserFilter{Email: "jane.doe@example.com"}, ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(users) != 1 || users[0].ID != 1 {
+		t.Errorf("unexpected users %+v", users)
+	}
+}
+
+func TestUserFilterMatch(t *testing.T) {
+	inactive := false
+	user := User{Email: "Jane.Doe@Example.com", RoleID: 2, DirectoryGroupIDs: []int{7, 9}}
+
+	for _, tc := range []struct {
+		filter UserFilter
+		want   bool
+	}{
+		{UserFilter{}, true},
+		{UserFilter{EmailDomain: "example.com"}, true},
+		{UserFilter{EmailDomain: "@EXAMPLE.COM"}, true},
+		{UserFilter{EmailDomain: "ample.com"}, false},
+		{UserFilter{EmailPattern: regexp.MustCompile(`(?i)^jane\.`)}, true},
+		{UserFilter{EmailPattern: regexp.MustCompile(`^jane\.`)}, false},
+		{UserFilter{RoleID: 2, DirectoryGroupID: 9}, true},
+		{UserFilter{DirectoryGroupID: 8}, false},
+		{UserFilter{Active: &inactive}, false},
+	} {
+		if got := tc.filter.Match(user); got != tc.want {
+			t.Errorf("%+v.Match() = %t, want %t", tc.filter, got, tc.want)
+		}
+	}
+}
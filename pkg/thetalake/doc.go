@@ -0,0 +1,23 @@
+// Package thetalake is a Go client for the Theta Lake API.
+//
+// Create a Client with NewClient and configure it with options:
+//
+//	c, err := thetalake.NewClient("https://api.thetalake.ai/api/v1", token,
+//		thetalake.WithRetry(3, time.Second, 10*time.Second),
+//		thetalake.WithUserAgent("review-bot/1.2"),
+//	)
+//
+// Every method takes a context that bounds the request, including retries and
+// waits for the rate limiter. Idempotent requests are retried after a rate
+// limit, a transient server error or a transport failure.
+//
+// Requests the API rejects return an *APIError carrying the status code, the
+// request and the error document. The helpers IsNotFound, IsConflict and so on
+// test for common statuses, and errors.Is matches the sentinels ErrNotFound,
+// ErrConflict and so on.
+//
+// List endpoints return a PageIterator that fetches one page at a time.
+//
+// Code that only calls the API should depend on the API interface, which
+// Client implements, so it can be tested against a fake.
+package thetalake
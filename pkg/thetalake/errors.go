@@ -0,0 +1,193 @@
+package thetalake
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// maxErrorBodySize caps how much of an error response body is kept.
+const maxErrorBodySize = 64 * 1024
+
+// ErrorPayload is the error document returned by the Theta Lake API.
+type ErrorPayload struct {
+	Code    string          `json:"code,omitempty"`
+	Message string          `json:"message,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Errors  json.RawMessage `json:"errors,omitempty"`
+}
+
+// Sentinel errors matched by an APIError with the corresponding status, so
+// callers can write errors.Is(err, thetalake.ErrNotFound).
+var (
+	ErrNotFound     = errors.New("thetalake: not found")
+	ErrUnauthorized = errors.New("thetalake: unauthorized")
+	ErrForbidden    = errors.New("thetalake: forbidden")
+	ErrConflict     = errors.New("thetalake: conflict")
+	ErrRateLimited  = errors.New("thetalake: rate limited")
+)
+
+// statusErrors maps HTTP status codes to their sentinel error.
+var statusErrors = map[int]error{
+	http.StatusNotFound:        ErrNotFound,
+	http.StatusUnauthorized:    ErrUnauthorized,
+	http.StatusForbidden:       ErrForbidden,
+	http.StatusConflict:        ErrConflict,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
+// APIError is returned when the Theta Lake API answers with an unexpected status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	RequestID  string
+	Payload    *ErrorPayload
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "status: %d", e.StatusCode)
+	if e.Method != "" {
+		fmt.Fprintf(&sb, ", request: %s %s", e.Method, e.URL)
+	}
+	if msg := e.Message(); msg != "" {
+		fmt.Fprintf(&sb, ", message: %s", msg)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&sb, ", request id: %s", e.RequestID)
+	}
+
+	return sb.String()
+}
+
+// Is reports whether target is the sentinel error for e's status code.
+func (e *APIError) Is(target error) bool {
+	sentinel, ok := statusErrors[e.StatusCode]
+	return ok && sentinel == target
+}
+
+// Message returns the most descriptive error message found in the response.
+func (e *APIError) Message() string {
+	if e.Payload != nil {
+		var parts []string
+		for _, s := range []string{e.Payload.Message, e.Payload.Error} {
+			if s != "" {
+				parts = append(parts, s)
+			}
+		}
+		if details := formatErrorDetails(e.Payload.Errors); details != "" {
+			parts = append(parts, details)
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, ": ")
+		}
+	}
+
+	return strings.TrimSpace(string(e.Body))
+}
+
+// formatErrorDetails flattens the "errors" member of an error payload, which
+// the API sends either as a list of messages or as a map of field errors.
+func formatErrorDetails(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return strings.Join(list, "; ")
+	}
+
+	var fields map[string][]string
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		keys := make([]string, 0, len(fields))
+		for field := range fields {
+			keys = append(keys, field)
+		}
+		sort.Strings(keys)
+
+		var out []string
+		for _, field := range keys {
+			out = append(out, fmt.Sprintf("%s %s", field, strings.Join(fields[field], ", ")))
+		}
+		return strings.Join(out, "; ")
+	}
+
+	return string(raw)
+}
+
+// newAPIError builds an APIError from a non-success response. The caller is
+// still responsible for closing the response body.
+func newAPIError(res *http.Response) error {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+		RequestID:  requestID(res.Header),
+	}
+
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.URL = res.Request.URL.Redacted()
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(res.Body, maxErrorBodySize))
+	apiErr.Body = body
+
+	var payload ErrorPayload
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Payload = &payload
+	}
+
+	return apiErr
+}
+
+func requestID(h http.Header) string {
+	for _, key := range []string{"X-Request-Id", "X-Correlation-Id", "X-Amzn-Requestid"} {
+		if v := h.Get(key); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
+
+// HasStatus reports whether err is an APIError with the given HTTP status code.
+func HasStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == statusCode
+	}
+
+	return false
+}
+
+// IsNotFound reports whether err was caused by a 404 response.
+func IsNotFound(err error) bool {
+	return HasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err was caused by a 401 response.
+func IsUnauthorized(err error) bool {
+	return HasStatus(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports whether err was caused by a 403 response.
+func IsForbidden(err error) bool {
+	return HasStatus(err, http.StatusForbidden)
+}
+
+// IsConflict reports whether err was caused by a 409 response.
+func IsConflict(err error) bool {
+	return HasStatus(err, http.StatusConflict)
+}
+
+// IsRateLimited reports whether err was caused by a 429 response.
+func IsRateLimited(err error) bool {
+	return HasStatus(err, http.StatusTooManyRequests)
+}
@@ -0,0 +1,77 @@
+package thetalake
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Case not found", "errors": {"id": ["is invalid"]}}`)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(server.URL, "token")
+
+	_, err := c.GetCase(t.Context(), "42")
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected status code %d", apiErr.StatusCode)
+	}
+	if apiErr.RequestID != "req-123" {
+		t.Errorf("unexpected request id %q", apiErr.RequestID)
+	}
+	if got, want := apiErr.Message(), "Case not found: id is invalid"; got != want {
+		t.Errorf("Message() = %q, want %q", got, want)
+	}
+	if !strings.Contains(err.Error(), "GET "+server.URL+"/cases/42") {
+		t.Errorf("error does not mention the request: %s", err)
+	}
+
+	if !IsNotFound(err) {
+		t.Error("IsNotFound should match a 404")
+	}
+	if IsConflict(err) {
+		t.Error("IsConflict should not match a 404")
+	}
+	if !IsNotFound(fmt.Errorf("wrapped: %w", err)) {
+		t.Error("IsNotFound should see through wrapped errors")
+	}
+	if !errors.Is(err, ErrNotFound) {
+		t.Error("errors.Is should match ErrNotFound for a 404")
+	}
+	if errors.Is(err, ErrConflict) {
+		t.Error("errors.Is should not match ErrConflict for a 404")
+	}
+}
+
+func TestAPIErrorPlainBody(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "upstream unavailable", http.StatusBadGateway)
+	})
+
+	err := c.DeleteTag(t.Context(), "1")
+	if !HasStatus(err, http.StatusBadGateway) {
+		t.Fatalf("expected a 502 APIError, got %v", err)
+	}
+
+	var apiErr *APIError
+	errors.As(err, &apiErr)
+	if got := apiErr.Message(); got != "upstream unavailable" {
+		t.Errorf("Message() = %q", got)
+	}
+}
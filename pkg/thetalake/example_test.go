@@ -0,0 +1,68 @@
+package thetalake_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/radugheorghies/thetalake-terraform-provider/pkg/thetalake"
+)
+
+func ExampleNewClient() {
+	c, err := thetalake.NewClient("https://api.thetalake.ai/api/v1", os.Getenv("THETALAKE_TOKEN"),
+		thetalake.WithRetry(3, time.Second, 10*time.Second),
+		thetalake.WithHTTPClient(&http.Client{Timeout: 30 * time.Second}),
+		thetalake.WithUserAgent("review-bot/1.2"),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	theCase, err := c.GetCase(context.Background(), "42")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(theCase.Number, theCase.Name)
+}
+
+func ExampleClient_ListAuditLogs() {
+	c, err := thetalake.NewClient("https://api.thetalake.ai/api/v1", os.Getenv("THETALAKE_TOKEN"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	it := c.ListAuditLogs(thetalake.AuditLogFilter{Action: "login"}, thetalake.ListOptions{PageSize: 100})
+	for it.Next(ctx) {
+		for _, entry := range it.Page() {
+			fmt.Println(entry.Timestamp, entry.User)
+		}
+	}
+	if err := it.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func ExampleAPIError() {
+	c, err := thetalake.NewClient("https://api.thetalake.ai/api/v1", os.Getenv("THETALAKE_TOKEN"))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	_, err = c.GetLegalHold(context.Background(), "7")
+
+	var apiErr *thetalake.APIError
+	switch {
+	case errors.Is(err, thetalake.ErrNotFound):
+		fmt.Println("legal hold 7 was released")
+	case errors.As(err, &apiErr):
+		log.Fatalf("API rejected the request (%d): %s", apiErr.StatusCode, apiErr.Message())
+	case err != nil:
+		log.Fatal(err)
+	}
+}
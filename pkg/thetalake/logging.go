@@ -0,0 +1,197 @@
+package thetalake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redacted replaces secret values in logged requests and responses.
+const redacted = "***"
+
+// maxLoggedBodySize caps how much of a body is written to the log.
+const maxLoggedBodySize = 16 * 1024
+
+// sensitiveHeaders are never logged in clear text.
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+// sensitiveKeys are the credential fields that are never logged in clear
+// text, besides any field starting with "password". Other keys that merely
+// mention a token, such as next_page_token, are paging state and must survive
+// a cassette replay.
+var sensitiveKeys = map[string]bool{
+	"token":         true,
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_secret": true,
+	"secret":        true,
+	"api_key":       true,
+	"apikey":        true,
+}
+
+// isSensitiveKey reports whether a JSON field, form field or query parameter
+// holds a credential.
+func isSensitiveKey(key string) bool {
+	k := strings.ToLower(key)
+
+	return strings.HasPrefix(k, "password") || sensitiveKeys[k]
+}
+
+// redactHeaders returns a copy of h suitable for logging.
+func redactHeaders(h http.Header) map[string]string {
+	out := make(map[string]string, len(h))
+	for k, v := range h {
+		out[k] = strings.Join(v, ", ")
+	}
+	for _, k := range sensitiveHeaders {
+		if _, ok := out[k]; ok {
+			out[k] = redacted
+		}
+	}
+
+	return out
+}
+
+// redactURL masks sensitive query parameters.
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	changed := false
+	for k := range q {
+		if isSensitiveKey(k) {
+			q.Set(k, redacted)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return u.Redacted()
+	}
+
+	clone := *u
+	clone.RawQuery = q.Encode()
+
+	return clone.Redacted()
+}
+
+// redactBody masks sensitive fields in a JSON or form-encoded body. Bodies in
+// other formats are logged as they are. The result is truncated to
+// maxLoggedBodySize.
+func redactBody(body []byte, contentType string) string {
+	return truncate(redactFullBody(body, contentType))
+}
+
+// redactFullBody is redactBody without the size limit, for callers that must
+// keep the whole body.
+func redactFullBody(body []byte, contentType string) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var doc any
+	if err := json.Unmarshal(body, &doc); err == nil {
+		if out, err := json.Marshal(redactJSON(doc)); err == nil {
+			return string(out)
+		}
+	}
+
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") {
+		if form, err := url.ParseQuery(string(body)); err == nil {
+			for k := range form {
+				if isSensitiveKey(k) {
+					form.Set(k, redacted)
+				}
+			}
+			return form.Encode()
+		}
+	}
+
+	return string(body)
+}
+
+func redactJSON(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, child := range v {
+			if isSensitiveKey(k) {
+				v[k] = redacted
+			} else {
+				v[k] = redactJSON(child)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			v[i] = redactJSON(child)
+		}
+	}
+
+	return v
+}
+
+func truncate(s string) string {
+	if len(s) <= maxLoggedBodySize {
+		return s
+	}
+
+	return s[:maxLoggedBodySize] + "...(truncated)"
+}
+
+// logRequest writes the outgoing request to the provider log: a summary at
+// DEBUG and the redacted headers and body at TRACE.
+func logRequest(ctx context.Context, req *http.Request, attempt int) {
+	fields := map[string]any{
+		"http_method":  req.Method,
+		"http_url":     redactURL(req.URL),
+		"http_attempt": attempt + 1,
+	}
+	tflog.Debug(ctx, "Sending Theta Lake API request", fields)
+
+	var body []byte
+	if req.GetBody != nil {
+		if rc, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(rc)
+			rc.Close()
+		}
+	}
+
+	fields["http_request_headers"] = redactHeaders(req.Header)
+	fields["http_request_body"] = redactBody(body, req.Header.Get("Content-Type"))
+	tflog.Trace(ctx, "Theta Lake API request details", fields)
+}
+
+// logResponse writes the response to the provider log. The body is read into
+// memory and replaced so the caller can still consume it.
+func logResponse(ctx context.Context, req *http.Request, res *http.Response, err error, latency time.Duration) {
+	fields := map[string]any{
+		"http_method":      req.Method,
+		"http_url":         redactURL(req.URL),
+		"http_duration_ms": latency.Milliseconds(),
+	}
+
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(ctx, "Theta Lake API request failed", fields)
+		return
+	}
+
+	fields["http_status_code"] = res.StatusCode
+	tflog.Debug(ctx, "Received Theta Lake API response", fields)
+
+	body, readErr := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		fields["error"] = readErr.Error()
+	}
+
+	fields["http_response_headers"] = redactHeaders(res.Header)
+	fields["http_response_body"] = redactBody(body, res.Header.Get("Content-Type"))
+	tflog.Trace(ctx, "Theta Lake API response details", fields)
+}
@@ -0,0 +1,82 @@
+package thetalake
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+)
+
+func TestRedactBody(t *testing.T) {
+	for name, tc := range map[string]struct {
+		body, contentType, want string
+	}{
+		"json": {
+			body: `{"email":"jane@example.com","password":"hunter2","password_confirmation":"hunter2"}`,
+			want: `{"email":"jane@example.com","password":"***","password_confirmation":"***"}`,
+		},
+		"nested json": {
+			body: `{"data":[{"id":1,"access_token":"abc"}],"meta":{"api_key":"k"}}`,
+			want: `{"data":[{"access_token":"***","id":1}],"meta":{"api_key":"***"}}`,
+		},
+		"form": {
+			body:        "client_secret=s3cret&grant_type=client_credentials",
+			contentType: "application/x-www-form-urlencoded",
+			want:        "client_secret=%2A%2A%2A&grant_type=client_credentials",
+		},
+		"paging": {
+			body: `{"next_page_token":"p2","token_type":"Bearer","token":"t"}`,
+			want: `{"next_page_token":"p2","token":"***","token_type":"Bearer"}`,
+		},
+		"plain text": {
+			body: "upstream unavailable",
+			want: "upstream unavailable",
+		},
+	} {
+		if got := redactBody([]byte(tc.body), tc.contentType); got != tc.want {
+			t.Errorf("%s: got %s, want %s", name, got, tc.want)
+		}
+	}
+}
+
+func TestDoRequestLogsRedactedWire(t *testing.T) {
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &logs)
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "hunter2") {
+			t.Errorf("the request body sent to the API was redacted: %s", body)
+		}
+		fmt.Fprint(w, `{"id": 7, "token": "server-issued"}`)
+	}))
+	c.Token = "super-secret-token"
+
+	req, _ := http.NewRequestWithContext(ctx, "POST", c.Endpoint+"/users?api_key=k&page_size=10", strings.NewReader(`{"password":"hunter2"}`))
+	res, err := c.DoRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(res.Body)
+	res.Body.Close()
+
+	if !strings.Contains(string(body), "server-issued") {
+		t.Errorf("the response body returned to the caller was altered: %s", body)
+	}
+
+	out := logs.String()
+	for _, secret := range []string{"super-secret-token", "hunter2", "server-issued", "api_key=k"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("log output leaks %q:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{`"http_method":"POST"`, `"http_status_code":200`, "http_duration_ms", "page_size=10"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output is missing %s:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package thetalake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenSource supplies the bearer token sent with every API request.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// invalidator is implemented by token sources that can drop a cached token
+// after the API rejected it, so the next call to Token fetches a fresh one.
+type invalidator interface {
+	Invalidate(token string)
+}
+
+// StaticToken is a TokenSource that always returns the same token.
+type StaticToken string
+
+func (t StaticToken) Token(ctx context.Context) (string, error) {
+	return string(t), nil
+}
+
+// expirySkew renews tokens slightly before they expire so that a request
+// started just before the deadline does not fail with a 401.
+const expirySkew = 30 * time.Second
+
+// ClientCredentialsConfig describes an OAuth2 client credentials grant.
+type ClientCredentialsConfig struct {
+	ClientID     string
+	ClientSecret string
+	TokenURL     string
+	Scopes       []string
+}
+
+// ClientCredentials is a TokenSource that exchanges a client ID and secret for
+// short-lived access tokens and caches them until they expire.
+type ClientCredentials struct {
+	config     ClientCredentialsConfig
+	httpClient *http.Client
+
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+}
+
+// NewClientCredentials creates a TokenSource for the client credentials grant.
+// If httpClient is nil, http.DefaultClient is used.
+func NewClientCredentials(config ClientCredentialsConfig, httpClient *http.Client) *ClientCredentials {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &ClientCredentials{
+		config:     config,
+		httpClient: httpClient,
+	}
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// Token returns the cached access token, requesting a new one if it is missing
+// or about to expire.
+func (s *ClientCredentials) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && (s.expiry.IsZero() || time.Now().Add(expirySkew).Before(s.expiry)) {
+		return s.token, nil
+	}
+
+	token, expiry, err := s.fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	s.token, s.expiry = token, expiry
+
+	return token, nil
+}
+
+// Invalidate drops token from the cache if it is still the current one.
+func (s *ClientCredentials) Invalidate(token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == token {
+		s.token = ""
+		s.expiry = time.Time{}
+	}
+}
+
+func (s *ClientCredentials) fetch(ctx context.Context) (string, time.Time, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(s.config.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.config.Scopes, " "))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.config.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(s.config.ClientID), url.QueryEscape(s.config.ClientSecret))
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("requesting OAuth2 token: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("requesting OAuth2 token: %w", newAPIError(res))
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	var tr tokenResponse
+	if err := json.Unmarshal(body, &tr); err != nil {
+		return "", time.Time{}, fmt.Errorf("decoding OAuth2 token response: %w", err)
+	}
+
+	if tr.AccessToken == "" {
+		return "", time.Time{}, fmt.Errorf("OAuth2 token response from %s did not contain an access_token", s.config.TokenURL)
+	}
+
+	if tr.TokenType != "" && !strings.EqualFold(tr.TokenType, "bearer") {
+		return "", time.Time{}, fmt.Errorf("unsupported OAuth2 token type %q", tr.TokenType)
+	}
+
+	var expiry time.Time
+	if tr.ExpiresIn > 0 {
+		expiry = time.Now().Add(time.Duration(tr.ExpiresIn) * time.Second)
+	}
+
+	return tr.AccessToken, expiry, nil
+}
@@ -0,0 +1,152 @@
+package thetalake
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// newTokenServer starts a token endpoint that hands out "token-1", "token-2", ...
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var issued atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != "client-id" || secret != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error": "invalid_client"}`)
+			return
+		}
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			t.Errorf("unexpected token request form %v", r.PostForm)
+		}
+		if got := r.PostForm.Get("scope"); got != "cases users" {
+			t.Errorf("unexpected scope %q", got)
+		}
+
+		n := issued.Add(1)
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "Bearer", "expires_in": %d}`, n, expiresIn)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &issued
+}
+
+func newOAuth2TestClient(t *testing.T, tokenURL, clientSecret string, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	c := newTestClient(t, handler)
+	c.Token = ""
+	c.TokenSource = NewClientCredentials(ClientCredentialsConfig{
+		ClientID:     "client-id",
+		ClientSecret: clientSecret,
+		TokenURL:     tokenURL,
+		Scopes:       []string{"cases", "users"},
+	}, nil)
+
+	return c
+}
+
+func TestClientCredentialsCachesToken(t *testing.T) {
+	tokenServer, issued := newTokenServer(t, 3600)
+
+	c := newOAuth2TestClient(t, tokenServer.URL, "s3cret", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer token-1" {
+			t.Errorf("unexpected Authorization header %q", got)
+		}
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	for i := 0; i < 3; i++ {
+		if _, err := c.GetTag(t.Context(), "1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if got := issued.Load(); got != 1 {
+		t.Errorf("expected one token request, got %d", got)
+	}
+}
+
+func TestClientCredentialsRefreshesExpiredToken(t *testing.T) {
+	// Tokens that expire within the skew window are renewed on every request.
+	tokenServer, issued := newTokenServer(t, 1)
+
+	c := newOAuth2TestClient(t, tokenServer.URL, "s3cret", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id": 1}`)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetTag(t.Context(), "1"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	if got := issued.Load(); got != 2 {
+		t.Errorf("expected two token requests, got %d", got)
+	}
+}
+
+func TestClientCredentialsRefreshesOnUnauthorized(t *testing.T) {
+	tokenServer, issued := newTokenServer(t, 3600)
+
+	var calls atomic.Int32
+	c := newOAuth2TestClient(t, tokenServer.URL, "s3cret", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		// The API has revoked the first token.
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id": 2, "name": "new"}`)
+	})
+
+	// POST is not retried for transient errors, but a rejected token is.
+	tag, err := c.CreateTag(t.Context(), Tag{Name: "new"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tag.ID != 2 {
+		t.Errorf("unexpected tag %+v", tag)
+	}
+	if got := issued.Load(); got != 2 {
+		t.Errorf("expected two token requests, got %d", got)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected two API requests, got %d", got)
+	}
+}
+
+func TestClientCredentialsGivesUpAfterOneRefresh(t *testing.T) {
+	tokenServer, _ := newTokenServer(t, 3600)
+
+	var calls atomic.Int32
+	c := newOAuth2TestClient(t, tokenServer.URL, "s3cret", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	if _, err := c.GetTag(t.Context(), "1"); !IsUnauthorized(err) {
+		t.Fatalf("expected a 401 APIError, got %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected two API requests, got %d", got)
+	}
+}
+
+func TestClientCredentialsInvalidClient(t *testing.T) {
+	tokenServer, _ := newTokenServer(t, 3600)
+
+	c := newOAuth2TestClient(t, tokenServer.URL, "wrong", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("the API should not be called without a token")
+	})
+
+	_, err := c.GetTag(t.Context(), "1")
+	if !IsUnauthorized(err) {
+		t.Fatalf("expected the token endpoint's 401, got %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package thetalake
+
+import (
+	"net/http"
+	"time"
+)
+
+// Version is the version of this package, reported in the default User-Agent.
+const Version = "0.1.0"
+
+// DefaultUserAgent is the User-Agent sent when none is configured.
+const DefaultUserAgent = "thetalake-go/" + Version
+
+// Option configures a Client created by NewClient.
+type Option func(*Client)
+
+// WithHTTPClient sends requests through httpClient instead of a default client
+// with DefaultRequestTimeout. Use NewHTTPClient to build one with a custom CA,
+// a client certificate or a proxy.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.HTTPClient = httpClient
+	}
+}
+
+// WithRetry sets how many times an idempotent request is retried and the
+// bounds of the exponential backoff between attempts. A maxRetries of zero
+// disables retries.
+func WithRetry(maxRetries int, waitMin, waitMax time.Duration) Option {
+	return func(c *Client) {
+		c.MaxRetries = maxRetries
+		c.RetryWaitMin = min(waitMin, waitMax)
+		c.RetryWaitMax = waitMax
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.UserAgent = userAgent
+	}
+}
+
+// WithTokenSource obtains the bearer token from ts instead of a static token.
+func WithTokenSource(ts TokenSource) Option {
+	return func(c *Client) {
+		c.TokenSource = ts
+	}
+}
+
+// WithRateLimit paces requests as described by Client.SetRateLimit.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) {
+		c.SetRateLimit(requestsPerSecond, burst)
+	}
+}
+
+// WithMaxConcurrency caps the requests in flight as described by
+// Client.SetMaxConcurrency.
+func WithMaxConcurrency(n int) Option {
+	return func(c *Client) {
+		c.SetMaxConcurrency(n)
+	}
+}
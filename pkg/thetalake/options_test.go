@@ -0,0 +1,65 @@
+package thetalake
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewClientOptions(t *testing.T) {
+	var attempts atomic.Int32
+	var userAgent atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		userAgent.Store(r.Header.Get("User-Agent"))
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	c, err := NewClient(server.URL+"/", "token",
+		WithHTTPClient(httpClient),
+		WithRetry(2, time.Millisecond, 5*time.Millisecond),
+		WithUserAgent("review-bot/1.2"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.HTTPClient != httpClient {
+		t.Error("WithHTTPClient was not applied")
+	}
+	if c.Endpoint != server.URL {
+		t.Errorf("trailing slash not trimmed from endpoint %q", c.Endpoint)
+	}
+
+	if _, err := c.GetSystemStatus(t.Context()); err == nil {
+		t.Fatal("expected an error")
+	}
+	if got := attempts.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+	if got := userAgent.Load(); got != "review-bot/1.2" {
+		t.Errorf("unexpected User-Agent %q", got)
+	}
+}
+
+func TestNewClientDefaults(t *testing.T) {
+	c, err := NewClient("https://api.thetalake.ai/api/v1", "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.UserAgent != DefaultUserAgent {
+		t.Errorf("unexpected User-Agent %q", c.UserAgent)
+	}
+	if c.MaxRetries != DefaultMaxRetries || c.RetryWaitMin != DefaultRetryWaitMin || c.RetryWaitMax != DefaultRetryWaitMax {
+		t.Errorf("unexpected retry settings %d %s %s", c.MaxRetries, c.RetryWaitMin, c.RetryWaitMax)
+	}
+
+	if _, err := NewClient("", "token"); err == nil {
+		t.Error("expected an empty endpoint to be rejected")
+	}
+}
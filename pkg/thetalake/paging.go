@@ -0,0 +1,219 @@
+package thetalake
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// ListOptions controls how a list endpoint is paged.
+type ListOptions struct {
+	// PageSize is the number of items requested per page. Zero leaves the
+	// page size to the API.
+	PageSize int
+	// MaxResults stops paging once this many items have been collected. Zero
+	// means no limit.
+	MaxResults int
+}
+
+// pageEnvelope holds the paging tokens the API may send next to a page of
+// items. Which one is set depends on the endpoint.
+type pageEnvelope struct {
+	NextCursor    string `json:"next_cursor"`
+	NextPageToken string `json:"next_page_token"`
+	Meta          struct {
+		NextCursor string `json:"next_cursor"`
+	} `json:"meta"`
+}
+
+func (e pageEnvelope) next() string {
+	switch {
+	case e.NextCursor != "":
+		return e.NextCursor
+	case e.NextPageToken != "":
+		return e.NextPageToken
+	default:
+		return e.Meta.NextCursor
+	}
+}
+
+// PageIterator walks a cursor-paginated list endpoint one page at a time.
+//
+//	it := c.ListEvents(thetalake.ListOptions{PageSize: 100})
+//	for it.Next(ctx) {
+//		for _, event := range it.Page() {
+//			...
+//		}
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type PageIterator[T any] struct {
+	client   *Client
+	path     string
+	query    url.Values
+	itemsKey string
+	pageSize int
+	// filter, when set, drops items from each page that it does not match.
+	// Filters repeat the query parameters on the client side so results are
+	// correct even when the API ignores some of them.
+	filter func(T) bool
+
+	cursor string
+	page   []T
+	done   bool
+	err    error
+}
+
+func newPageIterator[T any](c *Client, path string, query url.Values, itemsKey string, pageSize int) *PageIterator[T] {
+	if query == nil {
+		query = url.Values{}
+	}
+
+	return &PageIterator[T]{
+		client:   c,
+		path:     path,
+		query:    query,
+		itemsKey: itemsKey,
+		pageSize: pageSize,
+	}
+}
+
+// Next fetches the next page. It returns false when there are no pages left or
+// an error occurred; use Err to tell the two apart.
+func (it *PageIterator[T]) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+
+	items, next, err := it.fetch(ctx)
+	if err != nil {
+		it.err = err
+		it.page = nil
+		return false
+	}
+
+	// A missing or repeated cursor means the API has nothing more to give.
+	if next == "" || next == it.cursor {
+		it.done = true
+	}
+	it.cursor = next
+
+	if it.filter != nil {
+		kept := items[:0]
+		for _, item := range items {
+			if it.filter(item) {
+				kept = append(kept, item)
+			}
+		}
+		items = kept
+	}
+	it.page = items
+
+	return true
+}
+
+// Page returns the items of the page fetched by the last call to Next.
+func (it *PageIterator[T]) Page() []T {
+	return it.page
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *PageIterator[T]) Err() error {
+	return it.err
+}
+
+// All drains the iterator, stopping early once maxResults items have been
+// collected. A maxResults of zero collects every page.
+func (it *PageIterator[T]) All(ctx context.Context, maxResults int) ([]T, error) {
+	var all []T
+
+	for it.Next(ctx) {
+		all = append(all, it.Page()...)
+		if maxResults > 0 && len(all) >= maxResults {
+			return all[:maxResults], nil
+		}
+	}
+
+	return all, it.Err()
+}
+
+func (it *PageIterator[T]) fetch(ctx context.Context) ([]T, string, error) {
+	query := url.Values{}
+	for k, v := range it.query {
+		query[k] = v
+	}
+	if it.pageSize > 0 {
+		query.Set("page_size", fmt.Sprint(it.pageSize))
+	}
+	if it.cursor != "" {
+		query.Set("cursor", it.cursor)
+	}
+
+	u := fmt.Sprintf("%s%s", it.client.Endpoint, it.path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	res, err := it.client.DoRequest(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, "", newAPIError(res)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return decodePage[T](body, it.itemsKey)
+}
+
+// decodePage accepts either a bare JSON array, which is treated as the only
+// page, or an object carrying the items under itemsKey (or the generic "data"
+// and "items" keys) together with a paging token.
+func decodePage[T any](body []byte, itemsKey string) ([]T, string, error) {
+	var items []T
+
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &items); err != nil {
+			return nil, "", err
+		}
+		return items, "", nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(trimmed, &fields); err != nil {
+		return nil, "", err
+	}
+
+	for _, key := range []string{itemsKey, "data", "items"} {
+		if raw, ok := fields[key]; ok {
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return nil, "", err
+			}
+			break
+		}
+	}
+
+	var envelope pageEnvelope
+	if err := json.Unmarshal(trimmed, &envelope); err != nil {
+		return nil, "", err
+	}
+
+	return items, envelope.next(), nil
+}
@@ -0,0 +1,78 @@
+package thetalake
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestPageIteratorFollowsCursor(t *testing.T) {
+	var cursors []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("page_size"); got != "2" {
+			t.Errorf("unexpected page_size %q", got)
+		}
+
+		cursor := r.URL.Query().Get("cursor")
+		cursors = append(cursors, cursor)
+
+		switch cursor {
+		case "":
+			fmt.Fprint(w, `{"events": [{"id": "1"}, {"id": "2"}], "next_cursor": "abc"}`)
+		case "abc":
+			fmt.Fprint(w, `{"events": [{"id": "3"}, {"id": "4"}], "meta": {"next_cursor": "def"}}`)
+		default:
+			fmt.Fprint(w, `{"events": [{"id": "5"}]}`)
+		}
+	})
+
+	events, err := c.GetEvents(t.Context(), ListOptions{PageSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(events) != 5 || events[4].ID != "5" {
+		t.Errorf("unexpected events %+v", events)
+	}
+	if fmt.Sprint(cursors) != "[ abc def]" {
+		t.Errorf("unexpected cursors %q", cursors)
+	}
+}
+
+func TestPageIteratorMaxResults(t *testing.T) {
+	var calls int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"hits": [{"id": "a%[1]d"}, {"id": "b%[1]d"}], "next_page_token": "p%[1]d"}`, calls)
+	})
+
+	hits, err := c.GetAnalysisPolicyHits(t.Context(), ListOptions{MaxResults: 3})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(hits) != 3 {
+		t.Errorf("expected 3 hits, got %d", len(hits))
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 page requests, got %d", calls)
+	}
+}
+
+func TestPageIteratorBareArray(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"id": "1", "action": "login"}]`)
+	})
+
+	it := c.ListAuditLogs(AuditLogFilter{}, ListOptions{})
+	if !it.Next(t.Context()) {
+		t.Fatalf("expected a page, got error %v", it.Err())
+	}
+	if logs := it.Page(); len(logs) != 1 || logs[0].Action != "login" {
+		t.Errorf("unexpected page %+v", logs)
+	}
+	if it.Next(t.Context()) {
+		t.Error("a bare array should be the only page")
+	}
+	if it.Err() != nil {
+		t.Errorf("unexpected error: %s", it.Err())
+	}
+}
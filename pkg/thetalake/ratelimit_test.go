@@ -0,0 +1,77 @@
+package thetalake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterPacesRequests(t *testing.T) {
+	l := newRateLimiter(50, 2)
+
+	start := time.Now()
+	for i := 0; i < 6; i++ {
+		if err := l.Wait(t.Context()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Two requests use the burst, the remaining four are spaced 20ms apart.
+	if elapsed := time.Since(start); elapsed < 70*time.Millisecond {
+		t.Errorf("requests were not paced, took %s", elapsed)
+	}
+}
+
+func TestRateLimiterHonoursContext(t *testing.T) {
+	l := newRateLimiter(0.1, 1)
+	if err := l.Wait(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestMaxConcurrency(t *testing.T) {
+	var current, peak atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		w.Write([]byte(`{"id": 1}`))
+	})
+	c.SetMaxConcurrency(2)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.GetTag(t.Context(), "1"); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := peak.Load(); got > 2 {
+		t.Errorf("expected at most 2 requests in flight, saw %d", got)
+	}
+	if got := len(c.inflight); got != 0 {
+		t.Errorf("%d concurrency slots were never released", got)
+	}
+}
@@ -0,0 +1,106 @@
+package thetalake
+
+import (
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times an idempotent request is retried.
+	DefaultMaxRetries = 4
+	// DefaultRetryWaitMin is the backoff before the first retry.
+	DefaultRetryWaitMin = 1 * time.Second
+	// DefaultRetryWaitMax caps the backoff between two attempts.
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// isIdempotent reports whether a request with the given method can safely be
+// sent more than once.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
+
+// shouldRetry reports whether an attempt failed in a way that is worth retrying:
+// a transport error, a rate limit or a transient gateway failure.
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the given retry attempt (starting at
+// zero). A Retry-After header on the previous response takes precedence over
+// the exponential schedule; both are capped at RetryWaitMax.
+func (c *Client) backoff(attempt int, res *http.Response) time.Duration {
+	waitMin, waitMax := c.RetryWaitMin, c.RetryWaitMax
+	if waitMax < waitMin {
+		waitMax = waitMin
+	}
+
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return min(wait, waitMax)
+		}
+	}
+
+	wait := waitMin
+	for i := 0; i < attempt && wait < waitMax; i++ {
+		wait *= 2
+	}
+	wait = min(wait, waitMax)
+
+	// Jitter between half and the full backoff so parallel refreshes that hit
+	// the same limit do not retry in lockstep.
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int64N(half+1))
+	}
+
+	return wait
+}
+
+// parseRetryAfter understands both forms of the Retry-After header: a number
+// of seconds and an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(value); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// drainBody discards what is left of a response body so the underlying
+// connection can be reused for the next attempt.
+func drainBody(res *http.Response) {
+	if res == nil || res.Body == nil {
+		return
+	}
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, maxErrorBodySize))
+	res.Body.Close()
+}
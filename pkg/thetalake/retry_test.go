@@ -0,0 +1,151 @@
+package thetalake
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	c, err := NewClient(server.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.RetryWaitMin = time.Millisecond
+	c.RetryWaitMax = 10 * time.Millisecond
+
+	return c
+}
+
+func TestDoRequestRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"id": 7, "name": "tag"}`))
+		}
+	})
+
+	tag, err := c.GetTag(t.Context(), "7")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if tag.ID != 7 {
+		t.Errorf("unexpected tag %+v", tag)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestDoRequestReplaysBody(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var buf [64]byte
+		n, _ := r.Body.Read(buf[:])
+		if n == 0 {
+			t.Errorf("attempt %d was sent without a body", calls.Load()+1)
+		}
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write(buf[:n])
+	})
+
+	if _, err := c.UpdateTag(t.Context(), "1", Tag{Name: "renamed"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("expected 2 attempts, got %d", got)
+	}
+}
+
+func TestDoRequestDoesNotRetryPost(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := c.CreateTag(t.Context(), Tag{Name: "new"})
+	if !HasStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected a 503 APIError, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
+
+func TestDoRequestGivesUpAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.MaxRetries = 2
+
+	_, err := c.GetTag(t.Context(), "1")
+	if !HasStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected a 503 APIError, got %v", err)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := &Client{RetryWaitMin: time.Second, RetryWaitMax: 8 * time.Second}
+
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 8 * time.Second} {
+		got := c.backoff(attempt, nil)
+		if got < want/2 || got > want {
+			t.Errorf("attempt %d: backoff %s outside [%s, %s]", attempt, got, want/2, want)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{"Retry-After": []string{"3"}}}
+	if got := c.backoff(0, res); got != 3*time.Second {
+		t.Errorf("Retry-After not honoured, got %s", got)
+	}
+
+	res.Header.Set("Retry-After", "120")
+	if got := c.backoff(0, res); got != 8*time.Second {
+		t.Errorf("Retry-After not capped, got %s", got)
+	}
+}
+
+func TestDoRequestStopsWhenContextIsCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(t.Context())
+
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		cancel()
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	c.RetryWaitMin = time.Minute
+	c.RetryWaitMax = time.Minute
+
+	_, err := c.GetTag(ctx, "1")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single attempt, got %d", got)
+	}
+}
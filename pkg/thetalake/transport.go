@@ -0,0 +1,85 @@
+package thetalake
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// DefaultRequestTimeout bounds a single HTTP attempt, including reading the body.
+const DefaultRequestTimeout = 60 * time.Second
+
+// TransportConfig describes how the client reaches the API.
+type TransportConfig struct {
+	// CACertPEM holds extra PEM-encoded CA certificates to trust on top of the
+	// system pool, e.g. the CA of a TLS-inspecting proxy.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM enable mutual TLS when both are set.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// ProxyURL overrides the HTTPS_PROXY/HTTP_PROXY/NO_PROXY environment.
+	ProxyURL string
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool
+	// Timeout bounds a single attempt. Zero uses DefaultRequestTimeout.
+	Timeout time.Duration
+}
+
+// NewHTTPClient builds an *http.Client with a transport configured from cfg.
+func NewHTTPClient(cfg TransportConfig) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, errors.New("no valid PEM certificates found in the CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	switch {
+	case len(cfg.ClientCertPEM) > 0 && len(cfg.ClientKeyPEM) > 0:
+		cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	case len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0:
+		return nil, errors.New("a client certificate and a client key must be configured together")
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	if cfg.ProxyURL != "" {
+		proxy, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("parsing proxy URL: %w", err)
+		}
+		if proxy.Scheme == "" || proxy.Host == "" {
+			return nil, fmt.Errorf("proxy URL %q must include a scheme and a host", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = DefaultRequestTimeout
+	}
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
@@ -0,0 +1,88 @@
+package thetalake
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestNewHTTPClientCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "ok"}`)
+	}))
+	defer server.Close()
+
+	c, _ := NewClient(server.URL, "token")
+	c.MaxRetries = 0
+
+	httpClient, err := NewHTTPClient(TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.HTTPClient = httpClient
+	if _, err := c.GetSystemStatus(t.Context()); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected")
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	httpClient, err = NewHTTPClient(TransportConfig{CACertPEM: caPEM, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.HTTPClient = httpClient
+	if _, err := c.GetSystemStatus(t.Context()); err != nil {
+		t.Fatalf("expected the custom CA to be trusted: %s", err)
+	}
+	if httpClient.Timeout != 5*time.Second {
+		t.Errorf("unexpected timeout %s", httpClient.Timeout)
+	}
+}
+
+func TestNewHTTPClientInsecureSkipVerify(t *testing.T) {
+	httpClient, err := NewHTTPClient(TransportConfig{InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tlsConfig := httpClient.Transport.(*http.Transport).TLSClientConfig
+	if !tlsConfig.InsecureSkipVerify || tlsConfig.MinVersion != tls.VersionTLS12 {
+		t.Errorf("unexpected TLS config %+v", tlsConfig)
+	}
+	if httpClient.Timeout != DefaultRequestTimeout {
+		t.Errorf("unexpected default timeout %s", httpClient.Timeout)
+	}
+}
+
+func TestNewHTTPClientProxy(t *testing.T) {
+	httpClient, err := NewHTTPClient(TransportConfig{ProxyURL: "http://proxy.internal:3128"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, _ := http.NewRequest("GET", "https://api.thetalake.ai/api/v1/cases", nil)
+	proxy, err := httpClient.Transport.(*http.Transport).Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := url.Parse("http://proxy.internal:3128"); proxy.String() != want.String() {
+		t.Errorf("unexpected proxy %s", proxy)
+	}
+}
+
+func TestNewHTTPClientInvalidConfig(t *testing.T) {
+	for name, cfg := range map[string]TransportConfig{
+		"bad CA":           {CACertPEM: []byte("not a certificate")},
+		"cert without key": {ClientCertPEM: []byte("-----BEGIN CERTIFICATE-----")},
+		"bad key pair":     {ClientCertPEM: []byte("cert"), ClientKeyPEM: []byte("key")},
+		"relative proxy":   {ProxyURL: "proxy.internal:3128"},
+	} {
+		if _, err := NewHTTPClient(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}